@@ -0,0 +1,72 @@
+package wordle
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Adversary hosts an adversarial game, like Absurdle. Instead of picking a target up front it keeps every candidate
+// target that fits the patterns it has given so far, and it answers each guess with the pattern that keeps the most
+// candidates alive. The guess is only correct once it is the last candidate.
+type Adversary struct {
+	candidates []string
+}
+
+func NewAdversary(candidates []string) *Adversary {
+	return &Adversary{candidates: slices.Clone(candidates)}
+}
+
+// Candidates returns the targets that fit every pattern given so far
+func (this *Adversary) Candidates() []string {
+	return slices.Clone(this.candidates)
+}
+
+// Respond returns the pattern for the guess that keeps the most candidates and drops the candidates that don't fit it.
+// Ties go to the pattern with the fewest greens, then the fewest yellows, so the adversary gives away as little as
+// it can.
+func (this *Adversary) Respond(guess string) Pattern {
+	buckets := make(map[Pattern][]string)
+	for _, candidate := range this.candidates {
+		pattern := CheckGuess(candidate, guess)
+		buckets[pattern] = append(buckets[pattern], candidate)
+	}
+	var worst Pattern
+	for pattern, bucket := range buckets {
+		if worst.Len() == 0 || compareBuckets(pattern, bucket, worst, buckets[worst]) > 0 {
+			worst = pattern
+		}
+	}
+	this.candidates = buckets[worst]
+	return worst
+}
+
+func (this *Adversary) target() string {
+	if len(this.candidates) == 0 {
+		return ""
+	}
+	return this.candidates[0]
+}
+
+// compareBuckets returns a positive number if the adversary would rather answer with pattern a than pattern b
+func compareBuckets(a Pattern, aBucket []string, b Pattern, bBucket []string) int {
+	if c := cmp.Compare(len(aBucket), len(bBucket)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(countColor(b, Green), countColor(a, Green)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(countColor(b, Yellow), countColor(a, Yellow)); c != 0 {
+		return c
+	}
+	return slices.Compare(b[:], a[:])
+}
+
+func countColor(pattern Pattern, color LetterColor) int {
+	count := 0
+	for _, c := range pattern.Colors() {
+		if c == color {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,41 @@
+package wordle
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestAdversaryFixture(t *testing.T) {
+	gunit.Run(new(AdversaryFixture), t)
+}
+
+type AdversaryFixture struct {
+	*gunit.Fixture
+}
+
+func (this *AdversaryFixture) TestKeepsLargestBucket() {
+	adversary := NewAdversary([]string{"angry", "crane", "crank", "drank", "prank"})
+	pattern := adversary.Respond("crank")
+	this.So(pattern, should.Equal, Pattern{Gray, Green, Green, Green, Green})
+	this.So(adversary.Candidates(), should.Resemble, []string{"drank", "prank"})
+}
+
+func (this *AdversaryFixture) TestTieGoesToFewestGreens() {
+	adversary := NewAdversary([]string{"crane", "angry"})
+	pattern := adversary.Respond("crane")
+	this.So(pattern, should.Equal, Pattern{Gray, Yellow, Yellow, Yellow, Gray})
+	this.So(adversary.Candidates(), should.Resemble, []string{"angry"})
+}
+
+func (this *AdversaryFixture) TestCorrectOnlyForLastCandidate() {
+	adversary := NewAdversary([]string{"angry"})
+	this.So(adversary.Respond("angry").IsCorrect(), should.BeTrue)
+}
+
+func (this *AdversaryFixture) TestDoesNotChangeGivenCandidates() {
+	candidates := []string{"angry", "crane", "crank"}
+	NewAdversary(candidates).Respond("crane")
+	this.So(candidates, should.Resemble, []string{"angry", "crane", "crank"})
+}
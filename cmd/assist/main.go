@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tliddle1/wordle"
+	"github.com/tliddle1/wordle/pkg/solver"
+)
+
+const usage = `Usage: assist [flags]
+
+Coaches you through a game played somewhere else. After every guess, type it with the colors it got, like
+"soare BYGBB" or "soare ..YG.", and get the guesses that are expected to narrow the candidates down the most.
+
+Colors are G for green, Y for yellow and B or . for gray (see wordle.ParsePattern for the rest). Other commands:
+  undo   forget the last guess
+  new    start a new game
+  quit   stop
+
+Flags:
+`
+
+func main() {
+	hardMode := flag.Bool("hard", false, "only suggest guesses that use every hint")
+	numSuggestions := flag.Int("top", 5, "the number of guesses to suggest")
+	maxCandidates := flag.Int("list", 20, "list the candidates when there are at most this many")
+	language := flag.String("lang", wordle.DefaultLanguage, "the language of the word pack to play with")
+	packDir := flag.String("packs", wordle.DefaultWordPackDir(), "where word packs are looked for")
+	cacheDir := flag.String("cache", solver.DefaultCacheDir(), "where to cache the pattern table (no cache if empty)")
+	render := flag.String("render", "", "how guesses are drawn: ansi, high-contrast, plain, emoji, html or json "+
+		"(ansi unless NO_COLOR is set)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	renderer, err := wordle.RendererNamed(*render)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	dictionary, err := wordle.LoadLanguage(*language, *packDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	rules := dictionary.Rules()
+	rules.HardMode = *hardMode
+	options, err := solver.CachedPatternTableOptions(*cacheDir, []solver.ThomasSolverOption{
+		solver.WithDictionaryWords(dictionary),
+		solver.WithGameRules(rules),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "not using the pattern table cache:", err)
+	}
+	assistant := solver.NewThomasSolver(options...)
+
+	var turns []wordle.Turn
+	suggest(assistant, turns, *numSuggestions, *maxCandidates)
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 0:
+			continue
+		case fields[0] == "quit":
+			return
+		case fields[0] == "new":
+			turns = nil
+		case fields[0] == "undo":
+			if len(turns) > 0 {
+				turns = turns[:len(turns)-1]
+			}
+			for _, turn := range turns {
+				renderer.RenderTurn(os.Stdout, turn)
+			}
+		default:
+			turn, err := parseTurn(fields, rules.WordLength)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			renderer.RenderTurn(os.Stdout, turn)
+			if turn.Pattern.IsCorrect() {
+				fmt.Printf("Solved in %d guesses! Starting a new game.\n", len(turns)+1)
+				turns = nil
+				break
+			}
+			turns = append(turns, turn)
+		}
+		if !suggest(assistant, turns, *numSuggestions, *maxCandidates) && len(turns) > 0 {
+			fmt.Println("Forgetting the last guess, try it again.")
+			turns = turns[:len(turns)-1]
+		}
+	}
+}
+
+// parseTurn reads a guess followed by its pattern
+func parseTurn(fields []string, wordLength int) (wordle.Turn, error) {
+	if len(fields) < 2 {
+		return wordle.Turn{}, errors.New("type the guess and its colors, like \"soare BYGBB\"")
+	}
+	guess := wordle.NormalizeWord(fields[0])
+	if wordle.NumLetters(guess) != wordLength {
+		return wordle.Turn{}, fmt.Errorf("%w: \"%s\" does not have %d letters", wordle.ErrInvalidLengthGuess, guess, wordLength)
+	}
+	pattern, err := wordle.ParsePattern(strings.Join(fields[1:], ""))
+	if err != nil {
+		return wordle.Turn{}, err
+	}
+	if pattern.Len() != wordLength {
+		return wordle.Turn{}, fmt.Errorf("%w: %d colors for %d letters", wordle.ErrInvalidPattern, pattern.Len(), wordLength)
+	}
+	return wordle.Turn{Guess: guess, Pattern: pattern}, nil
+}
+
+// suggest prints what is left after the turns and what to guess next, returning false if no target fits the turns
+func suggest(assistant *solver.ThomasSolver, turns []wordle.Turn, numSuggestions, maxCandidates int) bool {
+	suggestions, err := assistant.Suggest(turns, numSuggestions)
+	if err != nil {
+		fmt.Println(err)
+		return false
+	}
+	candidates := assistant.Candidates()
+	if len(candidates) == 1 {
+		fmt.Printf("It's %s.\n", strings.ToUpper(candidates[0]))
+		return true
+	}
+	fmt.Printf("%d candidates left\n", len(candidates))
+	for i, suggestion := range suggestions {
+		note := ""
+		if suggestion.Candidate {
+			note = " (could be the answer)"
+		}
+		fmt.Printf("%3d. %s  %.2f bits%s\n", i+1, suggestion.Guess, suggestion.ExpectedInfo, note)
+	}
+	if len(candidates) <= maxCandidates {
+		fmt.Println("Candidates:", strings.Join(candidates, " "))
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tliddle1/wordle"
+	"github.com/tliddle1/wordle/pkg/solver"
+	"github.com/tliddle1/wordle/pkg/subprocess"
+)
+
+const usage = `Usage: compare [flags] solver solver...
+
+Every solver plays the same targets in the same order. A solver is one of:
+  thomas          the ThomasSolver
+  thomas:<word>   the ThomasSolver starting with <word>
+  exec:<command>  a solver that runs as <command>, like "exec:python3 solver.py" (see the README)
+
+Flags:
+`
+
+func main() {
+	seed := flag.Int64("seed", 0, "seed for the order the targets are played in (random if 0)")
+	sampleSize := flag.Int("sample", 0, "only play this many targets (all if 0)")
+	hardMode := flag.Bool("hard", false, "play in hard mode")
+	maxNumGuesses := flag.Int("max-guesses", wordle.MaxNumGuesses, "the number of guesses allowed per game")
+	cacheDir := flag.String("cache", solver.DefaultCacheDir(), "where to cache the pattern table (no cache if empty)")
+	guessTimeout := flag.Duration("timeout", 0, "the longest a solver may take to make a guess (no limit if 0)")
+	showTargets := flag.Bool("targets", false, "list the targets each solver did better on")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	rules := wordle.DefaultRules
+	rules.MaxNumGuesses = *maxNumGuesses
+	rules.HardMode = *hardMode
+	var solvers []wordle.NamedSolver
+	for _, spec := range flag.Args() {
+		newSolver, err := parseSolver(spec, rules, *cacheDir, *guessTimeout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		solvers = append(solvers, wordle.NamedSolver{Name: spec, NewSolver: newSolver})
+	}
+	if len(solvers) < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// A solver process that doesn't reply in time only loses the game, and the evaluation goes on as long as it gets to
+	// give up on its own
+	evaluatorTimeout := *guessTimeout
+	if slices.ContainsFunc(flag.Args(), func(spec string) bool { return strings.HasPrefix(spec, "exec:") }) {
+		evaluatorTimeout = subprocess.EvaluatorTimeout(*guessTimeout)
+	}
+	options := []wordle.Option{
+		wordle.WithRules(rules),
+		wordle.WithSampleSize(*sampleSize),
+		wordle.WithGuessTimeout(evaluatorTimeout),
+		wordle.WithOutput(io.Discard),
+	}
+	if *seed != 0 {
+		options = append(options, wordle.WithSeed(*seed))
+	}
+	evaluator := wordle.NewEvaluator(options...)
+	// Stopping with Ctrl+C or a failed evaluation still compares the games played so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	comparison, err := evaluator.CompareSolvers(ctx, runtime.NumCPU(), solvers...)
+	printComparison(comparison, *showTargets)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func parseSolver(spec string, rules wordle.Rules, cacheDir string, timeout time.Duration) (wordle.SolverFactory, error) {
+	name, argument, _ := strings.Cut(spec, ":")
+	switch name {
+	case "thomas":
+		options := []solver.ThomasSolverOption{solver.WithGameRules(rules)}
+		if argument != "" {
+			options = append(options, solver.WithOpener(argument))
+		}
+		options, err := solver.CachedPatternTableOptions(cacheDir, options)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "not using the pattern table cache:", err)
+		}
+		return func() wordle.Solver { return solver.NewThomasSolver(options...) }, nil
+	case "exec":
+		command := strings.Fields(argument)
+		if len(command) == 0 {
+			return nil, fmt.Errorf("no command in solver %q", spec)
+		}
+		return subprocess.NewFactory(command, subprocess.WithRules(rules), subprocess.WithTimeout(timeout)), nil
+	default:
+		return nil, fmt.Errorf("unknown solver %q", spec)
+	}
+}
+
+func printComparison(comparison *wordle.Comparison, showTargets bool) {
+	fmt.Printf("%d targets\n\n", len(comparison.Targets))
+	for i, report := range comparison.Reports {
+		fmt.Printf("%s: mean %.4f, win rate %.2f%%, max %d", comparison.Names[i], report.Mean, report.WinRate*100, report.Max)
+		if len(report.Games) < len(comparison.Targets) {
+			fmt.Printf(" (only %d games played)", len(report.Games))
+		}
+		fmt.Println()
+	}
+	for _, pair := range comparison.Pairs {
+		fmt.Printf("\n%s vs %s on %d targets\n", pair.A, pair.B, len(pair.Differences))
+		fmt.Printf("  mean difference: %+.4f guesses (95%% CI %+.4f to %+.4f, p = %.4f)\n",
+			pair.MeanDifference, pair.ConfidenceInterval[0], pair.ConfidenceInterval[1], pair.PValue)
+		if pair.Significant() {
+			fmt.Println("  the difference is significant")
+		} else {
+			fmt.Println("  the difference could be noise")
+		}
+		fmt.Printf("  %s did better on %d targets, %s on %d\n", pair.A, len(pair.AWins), pair.B, len(pair.BWins))
+		if showTargets {
+			printTargets(pair.A, pair.AWins)
+			printTargets(pair.B, pair.BWins)
+		}
+	}
+}
+
+func printTargets(name string, targets []string) {
+	if len(targets) > 0 {
+		fmt.Printf("  %s: %s\n", name, strings.Join(targets, " "))
+	}
+}
@@ -2,48 +2,258 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
-	"slices"
+	"time"
 
 	"github.com/tliddle1/wordle"
-	"github.com/tliddle1/wordle/data"
+	"github.com/tliddle1/wordle/pkg/daily"
+	"github.com/tliddle1/wordle/pkg/solver"
+	"github.com/tliddle1/wordle/pkg/stats"
 )
 
-var validGuesses = append(data.ValidTargets, data.ValidGuesses...)
-
 func main() {
-	target := data.ValidTargets[rand.Intn(len(data.ValidTargets))]
+	hardMode := flag.Bool("hard", false, "play in hard mode: every hint has to be used in later guesses")
+	maxNumGuesses := flag.Int("max-guesses", wordle.MaxNumGuesses, "the number of guesses allowed")
+	adversarial := flag.Bool("adversarial", false, "play against an adversary that avoids being found for as long as it can")
+	theme := flag.String("theme", "dark", "the squares the result is shared with: dark, light, high-contrast or high-contrast-light")
+	playDaily := flag.Bool("daily", false, "play today's puzzle, which is the same for everyone")
+	archive := flag.Int("archive", -1, "play the daily puzzle with this number")
+	targetsPath := flag.String("targets", "", "a file of targets, as text with a word on every line or a JSON array")
+	guessesPath := flag.String("guesses", "", "a file of guesses, which has to include the targets (the targets if not given)")
+	language := flag.String("lang", wordle.DefaultLanguage, "the language of the word pack to play with, unless -targets is given")
+	packDir := flag.String("packs", wordle.DefaultWordPackDir(), "where word packs are looked for")
+	statsPath := flag.String("stats", stats.DefaultPath(), "the file games are recorded in for statistics (none if empty)")
+	render := flag.String("render", "", "how the board is drawn: ansi, high-contrast, plain, emoji, html or json "+
+		"(ansi in a terminal unless NO_COLOR is set, plain otherwise)")
+	cacheDir := flag.String("cache", solver.DefaultCacheDir(), "where to cache the pattern table hints use (no cache if empty)")
+	schedulePath := flag.String("schedule", "", "a file of daily targets by date, like \"2024-01-31 crane\" on every line")
+	epoch := flag.String("epoch", daily.DefaultEpoch.Format(daily.DateLayout), "the day of daily puzzle 0")
+	seed := flag.Int64("seed", daily.DefaultSeed, "the seed for the order the daily targets are played in")
+	flag.Parse()
+
+	shareTheme, ok := wordle.ShareThemes[*theme]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown theme %q\n", *theme)
+		os.Exit(2)
+	}
+	renderer, err := chooseRenderer(*render, shareTheme)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	words, err := loadWords(*targetsPath, *guessesPath, *language, *packDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	rules := wordle.Rules{WordLength: words.WordLength(), MaxNumGuesses: *maxNumGuesses, HardMode: *hardMode}
+	var adversary *wordle.Adversary
+	var game *wordle.Game
+	var puzzleNumber *int // nil unless the game is a daily puzzle
+	title := ""
+	switch {
+	case *adversarial:
+		adversary = wordle.NewAdversary(words.Targets())
+		game, err = wordle.NewAdversarialGame(adversary, words, rules)
+	case *playDaily || *archive >= 0:
+		var number int
+		var target string
+		number, target, err = dailyPuzzle(words.Targets(), *archive, *schedulePath, *epoch, *seed)
+		if err == nil {
+			puzzleNumber = &number
+			title = fmt.Sprintf("Daily puzzle %d", number)
+			game, err = wordle.NewGame(target, words, rules)
+		}
+	default:
+		targets := words.Targets()
+		game, err = wordle.NewGame(targets[rand.Intn(len(targets))], words, rules)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	hints := hints{
+		options:  []solver.ThomasSolverOption{solver.WithDictionaryWords(words), solver.WithGameRules(rules)},
+		cacheDir: *cacheDir,
+	}
+	// The board is only redrawn in place, with ANSI escape codes, in a terminal that is getting ANSI tiles
+	_, ansi := renderer.(wordle.TileRenderer)
+	redraw := ansi && isTerminal(os.Stdout)
+	drawBoard(game, title, renderer, redraw)
 	scanner := bufio.NewScanner(os.Stdin)
-	won := false
-	for range wordle.MaxNumGuesses {
-		guess := askForGuess(scanner)
-		pattern := wordle.CheckGuess(target, guess)
-		wordle.PrintPattern(pattern, guess)
-		if pattern == wordle.CorrectPattern {
-			won = true
-			break
+	for game.Status() == wordle.GameInProgress {
+		guess, ok := askForGuess(scanner)
+		if !ok {
+			fmt.Printf("\nThe answer was %s.\n", game.Target())
+			return
+		}
+		if guess == "?" || guess == "hint" {
+			fmt.Println(hints.next(game.Turns()))
+			continue
+		}
+		pattern, err := game.Submit(guess)
+		var hardModeErr *wordle.HardModeError
+		if errors.As(err, &hardModeErr) {
+			fmt.Printf("Hard mode: %s, try again.\n", hardModeErr.Hint)
+			continue
+		}
+		if err != nil {
+			fmt.Println("Invalid guess, try again.")
+			continue
+		}
+		drawBoard(game, title, renderer, redraw)
+		if adversary != nil && !pattern.IsCorrect() {
+			fmt.Printf("%d words left\n", len(adversary.Candidates()))
 		}
 	}
-	if won {
+
+	switch {
+	case game.Status() == wordle.GameWon && adversary != nil:
+		fmt.Printf("You pinned it down in %d guesses!\n", len(game.Turns()))
+	case game.Status() == wordle.GameWon:
 		fmt.Println("You won!")
-	} else {
-		fmt.Printf("Sorry, you lost. The answer was %s.\n", target)
+	default:
+		fmt.Printf("Sorry, you lost. The answer was %s.\n", game.Target())
+	}
+	if hints.used > 0 {
+		fmt.Printf("Hints used: %d\n", hints.used)
+	}
+	if adversary == nil {
+		fmt.Printf("\n%s\n", game.Share(puzzleNumber).Format(shareTheme))
+		record := stats.NewRecord(game, puzzleNumber, time.Now())
+		record.Hints = hints.used
+		recordGame(*statsPath, record)
 	}
 }
 
-func askForGuess(scanner *bufio.Scanner) (guess string) {
-	validGuess := false
-	for !validGuess {
-		fmt.Print("Enter your guess: ")
-		scanner.Scan()
-		guess = scanner.Text()
-		if !slices.Contains(validGuesses, guess) {
-			fmt.Println("Invalid guess, try again.")
-		} else {
-			validGuess = true
+// hints gives hints from a solver, giving more away every time one is asked for until the next guess
+type hints struct {
+	options  []solver.ThomasSolverOption
+	cacheDir string         // where the pattern table is cached
+	hinter   *solver.Hinter // made the first time a hint is asked for
+	level    solver.HintLevel
+	numTurns int // the number of turns there were when the last hint was given
+	used     int
+}
+
+// next returns the next hint for the turns
+func (this *hints) next(turns []wordle.Turn) string {
+	if this.hinter == nil {
+		options, err := solver.CachedPatternTableOptions(this.cacheDir, this.options)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "not using the pattern table cache:", err)
+		}
+		this.hinter = solver.NewHinter(options...)
+	}
+	if len(turns) != this.numTurns {
+		this.level, this.numTurns = 0, len(turns)
+	}
+	this.level = min(this.level+1, solver.MaxHintLevel)
+	hint, err := this.hinter.Hint(turns, this.level)
+	if err != nil {
+		return err.Error()
+	}
+	this.used++
+	return hint.String()
+}
+
+// recordGame adds the game to the stats file, if there is one, and prints the stats
+func recordGame(path string, record stats.Record) {
+	if path == "" {
+		return
+	}
+	playerStats, err := stats.Add(path, record)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "the game wasn't recorded:", err)
+		return
+	}
+	fmt.Printf("\n%s", playerStats)
+}
+
+// chooseRenderer returns the renderer with the name, or if there is no name the default one when the output is a
+// terminal and PlainRenderer when it isn't. Emoji are drawn with the share theme.
+func chooseRenderer(name string, theme wordle.ShareTheme) (wordle.Renderer, error) {
+	switch {
+	case name == "" && !isTerminal(os.Stdout):
+		return wordle.PlainRenderer, nil
+	case name == "emoji":
+		return wordle.EmojiThemeRenderer{Theme: theme}, nil
+	}
+	return wordle.RendererNamed(name)
+}
+
+// drawBoard shows the guesses so far and the keyboard. With redraw the screen is cleared and everything is drawn again,
+// otherwise only the last guess is drawn, followed by the keyboard.
+func drawBoard(game *wordle.Game, title string, renderer wordle.Renderer, redraw bool) {
+	turns := game.Turns()
+	if redraw {
+		fmt.Print("\033[H\033[2J")
+	}
+	if title != "" && (redraw || len(turns) == 0) {
+		fmt.Println(title)
+	}
+	if !redraw && len(turns) == 0 {
+		return
+	}
+	if !redraw {
+		turns = turns[len(turns)-1:]
+	}
+	for _, turn := range turns {
+		renderer.RenderTurn(os.Stdout, turn)
+	}
+	fmt.Println()
+	renderer.RenderKeyboard(os.Stdout, wordle.NewKeyboard(game.Turns()))
+	fmt.Println()
+}
+
+// isTerminal returns true if the file is a terminal that understands ANSI escape codes
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0 && os.Getenv("TERM") != "dumb"
+}
+
+// askForGuess reads the next guess, returning false if there are no more
+func askForGuess(scanner *bufio.Scanner) (string, bool) {
+	fmt.Print("Enter your guess (? for a hint): ")
+	if !scanner.Scan() {
+		return "", false
+	}
+	return wordle.NormalizeWord(scanner.Text()), true
+}
+
+// loadWords returns the dictionary in the word list files if there are any, or else the word pack for the language
+func loadWords(targetsPath, guessesPath, language, packDir string) (*wordle.Dictionary, error) {
+	if targetsPath != "" {
+		return wordle.LoadDictionary(targetsPath, guessesPath)
+	}
+	return wordle.LoadLanguage(language, packDir)
+}
+
+// dailyPuzzle returns the number and target of today's puzzle, or of the puzzle with the archive number if it isn't
+// negative, counting from the epoch, a date like "2021-06-19"
+func dailyPuzzle(targets []string, archive int, schedulePath, epoch string, seed int64) (int, string, error) {
+	epochDate, err := time.Parse(daily.DateLayout, epoch)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid epoch: %w", err)
+	}
+	options := []daily.Option{daily.WithTargets(targets), daily.WithEpoch(epochDate), daily.WithSeed(seed)}
+	if schedulePath != "" {
+		schedule, err := daily.LoadSchedule(schedulePath)
+		if err != nil {
+			return 0, "", err
 		}
+		options = append(options, daily.WithSchedule(schedule))
+	}
+	calendar := daily.New(options...)
+	if archive < 0 {
+		return calendar.Today(time.Now())
 	}
-	return guess
+	target, err := calendar.Target(archive)
+	return archive, target, err
 }
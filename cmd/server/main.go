@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tliddle1/wordle/pkg/daily"
+	"github.com/tliddle1/wordle/pkg/server"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "the address to listen on")
+	sessionTTL := flag.Duration("session-ttl", 24*time.Hour, "how long a game is kept after it was last used")
+	schedulePath := flag.String("schedule", "", "a file of daily targets by date, like \"2024-01-31 crane\" on every line")
+	epoch := flag.String("epoch", daily.DefaultEpoch.Format(daily.DateLayout), "the day of daily puzzle 0")
+	seed := flag.Int64("seed", daily.DefaultSeed, "the seed for the order the daily targets are played in")
+	flag.Parse()
+
+	epochDate, err := time.Parse(daily.DateLayout, *epoch)
+	if err != nil {
+		log.Fatal("invalid -epoch: ", err)
+	}
+	calendarOptions := []daily.Option{daily.WithEpoch(epochDate), daily.WithSeed(*seed)}
+	if *schedulePath != "" {
+		schedule, err := daily.LoadSchedule(*schedulePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calendarOptions = append(calendarOptions, daily.WithSchedule(schedule))
+	}
+	handler := server.New(server.WithSessionTTL(*sessionTTL), server.WithCalendar(daily.New(calendarOptions...)))
+	log.Printf("serving games on http://%s/games", *addr)
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatal(httpServer.ListenAndServe())
+}
@@ -1,19 +1,156 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
 
 	"github.com/tliddle1/wordle"
 	"github.com/tliddle1/wordle/pkg/solver"
+	"github.com/tliddle1/wordle/pkg/subprocess"
 )
 
 func main() {
-	evaluator := wordle.NewEvaluator()
-	thomasSolver := solver.NewThomasSolver()
-	avgNumGuesses, err := evaluator.EvaluateSolver(thomasSolver)
+	failFast := flag.Bool("failfast", false, "stop at the first game that isn't won")
+	seed := flag.Int64("seed", 0, "seed for the order the targets are played in (random if 0)")
+	sampleSize := flag.Int("sample", 0, "only play this many targets (all if 0)")
+	quiet := flag.Bool("quiet", false, "don't print progress")
+	hardMode := flag.Bool("hard", false, "play in hard mode")
+	maxNumGuesses := flag.Int("max-guesses", wordle.MaxNumGuesses, "the number of guesses allowed per game")
+	numBoards := flag.Int("boards", 1, "the number of boards every guess is scored against, like 4 for Quordle")
+	adversarial := flag.Bool("adversarial", false, "play one game against an adversary instead of every target")
+	cacheDir := flag.String("cache", solver.DefaultCacheDir(), "where to cache the pattern table (no cache if empty)")
+	guessTimeout := flag.Duration("timeout", 0, "the longest the solver may take to make a guess (no limit if 0)")
+	command := flag.String("exec", "", "evaluate a solver that runs as this command instead (see the README for the protocol)")
+	language := flag.String("lang", wordle.DefaultLanguage, "the language of the word pack to play with")
+	packDir := flag.String("packs", wordle.DefaultWordPackDir(), "where word packs are looked for")
+	render := flag.String("render", "", "how the guesses of an adversarial game or a debug solver are drawn: ansi, "+
+		"high-contrast, plain, emoji, html or json (ansi unless NO_COLOR is set)")
+	flag.Parse()
+
+	renderer, err := wordle.RendererNamed(*render)
 	if err != nil {
-		fmt.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	dictionary, err := wordle.LoadLanguage(*language, *packDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	rules := wordle.DefaultRules
+	rules.WordLength = dictionary.WordLength()
+	rules.MaxNumGuesses = *maxNumGuesses
+	rules.HardMode = *hardMode
+	evaluatorTimeout := *guessTimeout
+	if *command != "" { // the solver process gives up on its own, so it only loses the game
+		evaluatorTimeout = subprocess.EvaluatorTimeout(*guessTimeout)
+	}
+	options := []wordle.Option{
+		wordle.WithRules(rules),
+		wordle.WithSampleSize(*sampleSize),
+		wordle.WithGuessTimeout(evaluatorTimeout),
+		wordle.WithDictionary(dictionary),
+		wordle.WithRenderer(renderer),
+	}
+	if *failFast {
+		options = append(options, wordle.WithFailFast())
+	}
+	if *seed != 0 {
+		options = append(options, wordle.WithSeed(*seed))
+	}
+	if *quiet {
+		options = append(options, wordle.WithOutput(io.Discard))
+	}
+	evaluator := wordle.NewEvaluator(options...)
+	if *command != "" && *numBoards > 1 {
+		fmt.Fprintln(os.Stderr, "-exec can't be used with -boards")
+		os.Exit(2)
+	}
+	if *hardMode && *numBoards > 1 {
+		fmt.Fprintln(os.Stderr, "-hard can't be used with -boards")
+		os.Exit(2)
+	}
+	var solverOptions []solver.ThomasSolverOption
+	var newSolver wordle.SolverFactory
+	if *command != "" {
+		newSolver = subprocess.NewFactory(strings.Fields(*command), subprocess.WithRules(rules),
+			subprocess.WithTimeout(*guessTimeout))
+	} else {
+		solverOptions, err = solver.CachedPatternTableOptions(*cacheDir, []solver.ThomasSolverOption{
+			solver.WithDictionaryWords(dictionary),
+			solver.WithGameRules(rules),
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "not using the pattern table cache:", err)
+		}
+		newSolver = func() wordle.Solver { return solver.NewThomasSolver(solverOptions...) }
+	}
+	if *adversarial {
+		game := evaluator.PlayAdversarialGame(newSolver())
+		for _, turn := range game.Turns {
+			renderer.RenderTurn(os.Stdout, turn)
+		}
+		if game.Err != nil {
+			fmt.Println(game.Err.Error())
+			return
+		}
+		fmt.Printf("Pinned down %s in %d guesses.\n", game.Target, game.NumGuesses)
 		return
 	}
-	fmt.Println(avgNumGuesses)
+
+	// Stopping with Ctrl+C still prints the report for the games played so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *numBoards > 1 {
+		multiSolver := solver.NewThomasMultiSolver(solverOptions...)
+		report, err := evaluator.EvaluateMultiSolverContext(ctx, multiSolver, *numBoards)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+		printMultiReport(report)
+		return
+	}
+	report, err := evaluator.EvaluateSolverParallelContext(ctx, newSolver, runtime.NumCPU())
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+	printReport(report)
+}
+
+func printReport(report *wordle.EvaluationReport) {
+	fmt.Printf("Win rate: %.2f%%\n", report.WinRate*100)
+	fmt.Printf("Mean: %.4f, median: %.1f, max: %d\n", report.Mean, report.Median, report.Max)
+	for numGuesses, count := range report.Histogram {
+		if numGuesses > 0 {
+			fmt.Printf("%d: %d\n", numGuesses, count)
+		}
+	}
+	for _, game := range report.Failures {
+		fmt.Println("failed:", game.Err)
+	}
+	fmt.Print("Worst targets:")
+	for _, game := range report.WorstTargets {
+		fmt.Printf(" %s (%d)", game.Target, game.NumGuesses)
+	}
+	fmt.Println()
+}
+
+func printMultiReport(report *wordle.MultiEvaluationReport) {
+	fmt.Printf("Win rate: %.2f%%\n", report.WinRate*100)
+	fmt.Printf("Mean: %.4f, max: %d\n", report.Mean, report.Max)
+	for numGuesses, count := range report.Histogram {
+		if numGuesses > 0 {
+			fmt.Printf("%d: %d\n", numGuesses, count)
+		}
+	}
+	for _, game := range report.Failures {
+		fmt.Println("failed:", game.Err, "solved on:", game.SolvedOn)
+	}
 }
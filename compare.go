@@ -0,0 +1,167 @@
+package wordle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+const (
+	confidenceLevel     = 0.95
+	numBootstrapSamples = 10000
+	bootstrapSeed       = 1
+)
+
+// NamedSolver is a solver entered into a comparison
+type NamedSolver struct {
+	Name      string
+	NewSolver SolverFactory
+}
+
+// Comparison reports how several solvers did on the same targets, played in the same order
+type Comparison struct {
+	Targets []string
+	Names   []string            // the solvers' names, in the order they were given
+	Reports []*EvaluationReport // Reports[i] is how the solver named Names[i] did
+	Pairs   []PairedComparison  // one for every pair of solvers
+}
+
+// PairedComparison compares two solvers target by target. A game that isn't won counts as one guess more than the
+// rules allow.
+type PairedComparison struct {
+	A, B string
+	// Differences[i] is how many more guesses A needed than B for the i-th target they both played, which is
+	// Comparison.Targets[i] unless an evaluation was cut short
+	Differences []int
+	AWins       []string // the targets A needed fewer guesses for
+	BWins       []string // the targets B needed fewer guesses for
+	// MeanDifference is how many more guesses A needs than B on average
+	MeanDifference float64
+	// ConfidenceInterval is a bootstrapped 95% confidence interval for MeanDifference
+	ConfidenceInterval [2]float64
+	// PValue is the two-sided p-value of a paired t-test that A and B need the same number of guesses on average,
+	// using the normal approximation
+	PValue float64
+}
+
+// Significant returns true if the difference between A and B is unlikely to be noise
+func (this PairedComparison) Significant() bool {
+	return this.PValue < 1-confidenceLevel
+}
+
+// CompareSolvers evaluates each solver on the evaluator's targets and compares every pair of them. If an evaluation
+// fails, like when ctx is done or a solver takes too long to guess, the solvers after it aren't evaluated and the
+// comparison of the games played so far is returned with the error.
+func (this *Evaluator) CompareSolvers(ctx context.Context, numWorkers int, solvers ...NamedSolver) (*Comparison, error) {
+	comparison := Comparison{Targets: this.Targets()}
+	var err error
+	for _, solver := range solvers {
+		var report *EvaluationReport
+		report, err = this.EvaluateSolverParallelContext(ctx, solver.NewSolver, numWorkers)
+		comparison.Names = append(comparison.Names, solver.Name)
+		comparison.Reports = append(comparison.Reports, report)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", solver.Name, err)
+			break
+		}
+	}
+	for i := range comparison.Reports {
+		for j := i + 1; j < len(comparison.Reports); j++ {
+			pair := this.comparePair(comparison.Reports[i], comparison.Reports[j])
+			pair.A, pair.B = comparison.Names[i], comparison.Names[j]
+			comparison.Pairs = append(comparison.Pairs, pair)
+		}
+	}
+	return &comparison, err
+}
+
+// comparePair compares the games both reports have, which are all of them unless an evaluation was cut short. An
+// evaluation that was cancelled can be missing games from the middle, so the games are matched up by their targets.
+func (this *Evaluator) comparePair(a, b *EvaluationReport) PairedComparison {
+	bGames := make(map[string]GameResult, len(b.Games))
+	for _, game := range b.Games {
+		bGames[game.Target] = game
+	}
+	var pair PairedComparison
+	for _, aGame := range a.Games {
+		bGame, ok := bGames[aGame.Target]
+		if !ok {
+			continue
+		}
+		difference := this.score(aGame) - this.score(bGame)
+		pair.Differences = append(pair.Differences, difference)
+		if difference < 0 {
+			pair.AWins = append(pair.AWins, aGame.Target)
+		} else if difference > 0 {
+			pair.BWins = append(pair.BWins, aGame.Target)
+		}
+	}
+	pair.MeanDifference = mean(pair.Differences)
+	pair.ConfidenceInterval = bootstrapConfidenceInterval(pair.Differences)
+	pair.PValue = pairedPValue(pair.Differences)
+	return pair
+}
+
+// score returns the number of guesses a game took, counting a game that wasn't won as one guess more than allowed
+func (this *Evaluator) score(game GameResult) int {
+	if !game.Won() {
+		return this.rules.MaxNumGuesses + 1
+	}
+	return game.NumGuesses
+}
+
+func mean(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return float64(total) / float64(len(values))
+}
+
+// bootstrapConfidenceInterval returns the percentile bootstrap confidence interval for the mean of values
+func bootstrapConfidenceInterval(values []int) [2]float64 {
+	if len(values) == 0 {
+		return [2]float64{}
+	}
+	random := rand.New(rand.NewSource(bootstrapSeed))
+	means := make([]float64, numBootstrapSamples)
+	for i := range means {
+		total := 0
+		for range values {
+			total += values[random.Intn(len(values))]
+		}
+		means[i] = float64(total) / float64(len(values))
+	}
+	slices.Sort(means)
+	tail := (1 - confidenceLevel) / 2
+	low := int(tail * float64(numBootstrapSamples))
+	high := int((1-tail)*float64(numBootstrapSamples)) - 1
+	return [2]float64{means[low], means[high]}
+}
+
+// pairedPValue returns the two-sided p-value of a paired t-test that the mean of the differences is 0, approximating
+// the t distribution with the normal distribution
+func pairedPValue(differences []int) float64 {
+	if len(differences) < 2 {
+		return 1
+	}
+	average := mean(differences)
+	sumOfSquares := float64(0)
+	for _, difference := range differences {
+		sumOfSquares += (float64(difference) - average) * (float64(difference) - average)
+	}
+	standardError := math.Sqrt(sumOfSquares/float64(len(differences)-1)) / math.Sqrt(float64(len(differences)))
+	if standardError == 0 {
+		if average == 0 {
+			return 1
+		}
+		return 0
+	}
+	t := average / standardError
+	return math.Erfc(math.Abs(t) / math.Sqrt2)
+}
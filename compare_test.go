@@ -0,0 +1,106 @@
+package wordle
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestCompareFixture(t *testing.T) {
+	gunit.Run(new(CompareFixture), t)
+}
+
+type CompareFixture struct {
+	*gunit.Fixture
+	Evaluator *Evaluator
+}
+
+func (this *CompareFixture) Setup() {
+	targets := []string{"angry", "crane", "salet"}
+	this.Evaluator = NewEvaluator(WithTargets(targets), WithoutShuffle(), WithOutput(io.Discard))
+}
+
+func (this *CompareFixture) TestCompareSolvers() {
+	forwards := NamedSolver{Name: "forwards", NewSolver: func() Solver { return NewDummySolverFixedGuesses("angry", "crane", "salet") }}
+	backwards := NamedSolver{Name: "backwards", NewSolver: func() Solver { return NewDummySolverFixedGuesses("salet", "crane", "angry") }}
+	oneGuess := NamedSolver{Name: "one guess", NewSolver: NewDummySolverOneGuess}
+	comparison, err := this.Evaluator.CompareSolvers(context.Background(), 2, forwards, backwards, oneGuess)
+	this.So(err, should.BeNil)
+	this.So(comparison.Names, should.Resemble, []string{"forwards", "backwards", "one guess"})
+	this.So(comparison.Reports, should.HaveLength, 3)
+	this.So(comparison.Pairs, should.HaveLength, 3)
+
+	pair := comparison.Pairs[0]
+	this.So(pair.A, should.Equal, "forwards")
+	this.So(pair.B, should.Equal, "backwards")
+	this.So(pair.Differences, should.Resemble, []int{-2, 0, 2})
+	this.So(pair.AWins, should.Resemble, []string{"angry"})
+	this.So(pair.BWins, should.Resemble, []string{"salet"})
+	this.So(pair.MeanDifference, should.Equal, 0)
+	this.So(pair.Significant(), should.BeFalse)
+
+	pair = comparison.Pairs[1]
+	this.So(pair.A, should.Equal, "forwards")
+	this.So(pair.B, should.Equal, "one guess")
+	this.So(pair.Differences, should.Resemble, []int{1 - 7, 2 - 7, 3 - 1})
+}
+
+func (this *CompareFixture) TestComparePairShorterReport() {
+	a := newEvaluationReport([]GameResult{{Target: "angry", NumGuesses: 1}, {Target: "crane", NumGuesses: 2}})
+	b := newEvaluationReport([]GameResult{{Target: "angry", NumGuesses: 3}})
+	this.So(this.Evaluator.comparePair(a, b).Differences, should.Resemble, []int{-2})
+	this.So(this.Evaluator.comparePair(b, a).Differences, should.Resemble, []int{2})
+
+	c := newEvaluationReport([]GameResult{{Target: "crane", NumGuesses: 4}})
+	pair := this.Evaluator.comparePair(a, c)
+	this.So(pair.Differences, should.Resemble, []int{-2})
+	this.So(pair.AWins, should.Resemble, []string{"crane"})
+}
+
+func (this *CompareFixture) TestCompareSolversError() {
+	release := make(chan struct{})
+	defer close(release)
+	evaluator := NewEvaluator(WithTargets([]string{"angry", "crane", "salet"}), WithOutput(io.Discard),
+		WithGuessTimeout(100*time.Millisecond))
+	oneGuess := NamedSolver{Name: "one guess", NewSolver: NewDummySolverOneGuess}
+	hanging := NamedSolver{Name: "hanging", NewSolver: func() Solver { return NewDummySolverHanging(release, 0) }}
+	notPlayed := NamedSolver{Name: "not played", NewSolver: NewDummySolverOneGuess}
+	comparison, err := evaluator.CompareSolvers(context.Background(), 1, oneGuess, hanging, notPlayed)
+	this.So(err, should.Wrap, ErrGuessTimeout)
+	this.So(comparison.Names, should.Resemble, []string{"one guess", "hanging"})
+	this.So(comparison.Reports[0].Games, should.HaveLength, len(comparison.Targets))
+	this.So(comparison.Reports[1].Games, should.HaveLength, 1)
+	this.So(comparison.Pairs, should.HaveLength, 1)
+	this.So(comparison.Pairs[0].Differences, should.HaveLength, 1)
+}
+
+func (this *CompareFixture) TestCompareSolversCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	oneGuess := NamedSolver{Name: "one guess", NewSolver: NewDummySolverOneGuess}
+	comparison, err := this.Evaluator.CompareSolvers(ctx, 1, oneGuess, oneGuess)
+	this.So(err, should.Wrap, context.Canceled)
+	this.So(comparison.Names, should.Resemble, []string{"one guess"})
+	this.So(comparison.Pairs, should.BeEmpty)
+}
+
+func (this *CompareFixture) TestBootstrapConfidenceInterval() {
+	differences := []int{-1, 0, -2, -1, 0, -1, -1, 0, -3, -1}
+	interval := bootstrapConfidenceInterval(differences)
+	this.So(interval[0], should.BeLessThan, mean(differences))
+	this.So(interval[1], should.BeGreaterThan, mean(differences))
+	this.So(interval[1], should.BeLessThan, 0)
+	this.So(bootstrapConfidenceInterval(differences), should.Resemble, interval)
+}
+
+func (this *CompareFixture) TestPairedPValue() {
+	this.So(pairedPValue(nil), should.Equal, 1)
+	this.So(pairedPValue([]int{0, 0, 0}), should.Equal, 1)
+	this.So(pairedPValue([]int{1, 1, 1}), should.Equal, 0)
+	this.So(pairedPValue([]int{1, -1, 1, -1}), should.Equal, 1)
+	this.So(pairedPValue([]int{-1, 0, -2, -1, 0, -1, -1, 0, -3, -1}), should.BeLessThan, 0.001)
+}
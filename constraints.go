@@ -0,0 +1,279 @@
+package wordle
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Constraints is what a turn history says about the target. A word matches the constraints if and only if it would have
+// given every pattern in the history, as long as the patterns are ones CheckGuess can return.
+type Constraints struct {
+	WordLength  int            // the number of letters the target has, 0 if there are no turns and -1 if they disagree
+	Greens      []rune         // the letter known to be at every position, or 0 if it isn't known
+	Forbidden   map[rune][]int // the positions every letter is known not to be at, in order
+	MinCounts   map[rune]int   // how many times every letter is known to be in the target at least
+	ExactCounts map[rune]int   // how many times letters are known to be in the target exactly, 0 if they aren't in it
+}
+
+// NewConstraints returns the constraints the turn history puts on the target
+func NewConstraints(turnHistory []Turn) Constraints {
+	constraints := newConstraints(0)
+	for _, turn := range turnHistory {
+		constraints = constraints.Merge(turnConstraints(turn))
+	}
+	return constraints
+}
+
+func newConstraints(wordLength int) Constraints {
+	return Constraints{
+		WordLength:  wordLength,
+		Greens:      make([]rune, max(wordLength, 0)),
+		Forbidden:   make(map[rune][]int),
+		MinCounts:   make(map[rune]int),
+		ExactCounts: make(map[rune]int),
+	}
+}
+
+// turnConstraints returns the constraints a single turn puts on the target. A letter that is gray anywhere in the guess
+// is in the target exactly as many times as it is green or yellow, and it isn't at any position it is gray or yellow.
+func turnConstraints(turn Turn) Constraints {
+	letters := []rune(turn.Guess)
+	constraints := newConstraints(len(letters))
+	colors := turn.Pattern.Colors()
+	hasGray := make(map[rune]bool)
+	for i, letter := range letters {
+		color := Gray
+		if i < len(colors) {
+			color = colors[i]
+		}
+		switch color {
+		case Green:
+			constraints.Greens[i] = letter
+			constraints.MinCounts[letter]++
+		case Yellow:
+			constraints.MinCounts[letter]++
+			constraints.Forbidden[letter] = append(constraints.Forbidden[letter], i)
+		default:
+			hasGray[letter] = true
+			constraints.Forbidden[letter] = append(constraints.Forbidden[letter], i)
+		}
+	}
+	for letter := range hasGray {
+		constraints.ExactCounts[letter] = constraints.MinCounts[letter]
+	}
+	return constraints
+}
+
+// Merge returns the constraints of both this and other, which no word matches if they contradict each other
+func (this Constraints) Merge(other Constraints) Constraints {
+	wordLength := this.WordLength
+	switch {
+	case wordLength == 0:
+		wordLength = other.WordLength
+	case other.WordLength != 0 && other.WordLength != wordLength:
+		wordLength = -1
+	}
+	merged := newConstraints(wordLength)
+	for _, from := range []Constraints{this, other} {
+		for i, letter := range from.Greens {
+			if letter == 0 || i >= len(merged.Greens) {
+				continue
+			}
+			if merged.Greens[i] == 0 {
+				merged.Greens[i] = letter
+			} else if merged.Greens[i] != letter {
+				// Two different letters can't both be at the position, so forbidding one of them rules out every word
+				merged.Forbidden[merged.Greens[i]] = append(merged.Forbidden[merged.Greens[i]], i)
+			}
+		}
+		for letter, positions := range from.Forbidden {
+			merged.Forbidden[letter] = append(merged.Forbidden[letter], positions...)
+		}
+		for letter, count := range from.MinCounts {
+			merged.MinCounts[letter] = max(merged.MinCounts[letter], count)
+		}
+		for letter, count := range from.ExactCounts {
+			if exact, ok := merged.ExactCounts[letter]; ok && exact != count {
+				// Only a count can be kept, so the minimum is raised past it for no word to match
+				merged.MinCounts[letter] = max(merged.MinCounts[letter], exact, count)
+				count = min(exact, count)
+			}
+			merged.ExactCounts[letter] = count
+		}
+	}
+	greenCounts := letterCounts(merged.Greens)
+	for letter, count := range greenCounts {
+		if letter != 0 {
+			merged.MinCounts[letter] = max(merged.MinCounts[letter], count)
+		}
+	}
+	for letter, positions := range merged.Forbidden {
+		slices.Sort(positions)
+		merged.Forbidden[letter] = slices.Compact(positions)
+	}
+	return merged
+}
+
+// Matches returns true if the word could be the target
+func (this Constraints) Matches(word string) bool {
+	if this.WordLength == 0 {
+		return true
+	}
+	letters := []rune(word)
+	if len(letters) != this.WordLength {
+		return false
+	}
+	for i, letter := range this.Greens {
+		if letter != 0 && letters[i] != letter {
+			return false
+		}
+	}
+	for letter, positions := range this.Forbidden {
+		for _, i := range positions {
+			if letters[i] == letter {
+				return false
+			}
+		}
+	}
+	counts := letterCounts(letters)
+	for letter, count := range this.MinCounts {
+		if counts[letter] < count {
+			return false
+		}
+	}
+	for letter, count := range this.ExactCounts {
+		if counts[letter] != count {
+			return false
+		}
+	}
+	return true
+}
+
+// Absent returns the letters known not to be in the target, in order
+func (this Constraints) Absent() []rune {
+	var absent []rune
+	for letter, count := range this.ExactCounts {
+		if count == 0 {
+			absent = append(absent, letter)
+		}
+	}
+	slices.Sort(absent)
+	return absent
+}
+
+// Present returns the letters known to be in the target, in order
+func (this Constraints) Present() []rune {
+	var present []rune
+	for letter, count := range this.MinCounts {
+		if count > 0 {
+			present = append(present, letter)
+		}
+	}
+	slices.Sort(present)
+	return present
+}
+
+// String summarizes the constraints, like "S?A?? · has N, not 5th · has E exactly once, not 1st · no I, L, T"
+func (this Constraints) String() string {
+	switch this.WordLength {
+	case 0:
+		return "anything"
+	case -1:
+		return "nothing, the guesses have different lengths"
+	}
+	var greens strings.Builder
+	for _, letter := range this.Greens {
+		if letter == 0 {
+			greens.WriteByte('?')
+		} else {
+			greens.WriteString(upper(letter))
+		}
+	}
+	parts := []string{greens.String()}
+	greenCounts := letterCounts(this.Greens)
+	for _, letter := range this.Present() {
+		count, isExact := this.ExactCounts[letter]
+		positions := this.Forbidden[letter]
+		if !isExact && len(positions) == 0 && this.MinCounts[letter] <= greenCounts[letter] {
+			continue // everything known about the letter is where it is green
+		}
+		part := "has " + upper(letter)
+		switch {
+		case isExact && count == 1:
+			part += " exactly once"
+		case isExact:
+			part += fmt.Sprintf(" exactly %d times", count)
+		case this.MinCounts[letter] > 1:
+			part += fmt.Sprintf(" at least %d times", this.MinCounts[letter])
+		}
+		if len(positions) > 0 {
+			part += ", not " + ordinals(positions)
+		}
+		parts = append(parts, part)
+	}
+	if absent := this.Absent(); len(absent) > 0 {
+		letters := make([]string, len(absent))
+		for i, letter := range absent {
+			letters[i] = upper(letter)
+		}
+		parts = append(parts, "no "+strings.Join(letters, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Regexp returns a regular expression for the letters every position can have, like for searching a word list with
+// grep. It can't count letters, so words it matches have to be checked with Matches, but words it doesn't match can't
+// be the target.
+func (this Constraints) Regexp() *regexp.Regexp {
+	switch this.WordLength {
+	case 0:
+		return regexp.MustCompile(`^.*$`)
+	case -1:
+		return regexp.MustCompile(`^[^\x00-\x{10FFFF}]$`)
+	}
+	excluded := make([][]rune, this.WordLength)
+	for letter, positions := range this.Forbidden {
+		for _, i := range positions {
+			if i < this.WordLength {
+				excluded[i] = append(excluded[i], letter)
+			}
+		}
+	}
+	absent := this.Absent()
+	var expression strings.Builder
+	expression.WriteByte('^')
+	for i, letter := range this.Greens {
+		if letter != 0 {
+			expression.WriteString(regexp.QuoteMeta(string(letter)))
+			continue
+		}
+		letters := append(slices.Clone(absent), excluded[i]...)
+		slices.Sort(letters)
+		letters = slices.Compact(letters)
+		if len(letters) == 0 {
+			expression.WriteByte('.')
+			continue
+		}
+		expression.WriteString("[^")
+		for _, letter := range letters {
+			expression.WriteString(regexp.QuoteMeta(string(letter)))
+		}
+		expression.WriteByte(']')
+	}
+	expression.WriteByte('$')
+	return regexp.MustCompile(expression.String())
+}
+
+// ordinals lists the positions as ordinals, like "1st, 3rd or 5th"
+func ordinals(positions []int) string {
+	words := make([]string, len(positions))
+	for i, position := range positions {
+		words[i] = ordinal(position + 1)
+	}
+	if len(words) == 1 {
+		return words[0]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " or " + words[len(words)-1]
+}
@@ -0,0 +1,108 @@
+package wordle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle/data"
+)
+
+func TestConstraintsFixture(t *testing.T) {
+	gunit.Run(new(ConstraintsFixture), t)
+}
+
+type ConstraintsFixture struct {
+	*gunit.Fixture
+}
+
+func turnsFor(target string, guesses ...string) []Turn {
+	turns := make([]Turn, len(guesses))
+	for i, guess := range guesses {
+		turns[i] = Turn{Guess: guess, Pattern: CheckGuess(target, guess)}
+	}
+	return turns
+}
+
+func (this *ConstraintsFixture) TestFromTurns() {
+	constraints := NewConstraints(turnsFor("sheen", "elate", "siren"))
+	this.So(constraints.WordLength, should.Equal, 5)
+	this.So(constraints.Greens, should.Resemble, []rune{'s', 0, 0, 'e', 'n'})
+	this.So(constraints.Forbidden['e'], should.Resemble, []int{0, 4})
+	this.So(constraints.MinCounts['e'], should.Equal, 2)
+	this.So(constraints.ExactCounts, should.Resemble, map[rune]int{'l': 0, 'a': 0, 't': 0, 'i': 0, 'r': 0})
+	this.So(constraints.Present(), should.Resemble, []rune{'e', 'n', 's'})
+	this.So(constraints.Absent(), should.Resemble, []rune{'a', 'i', 'l', 'r', 't'})
+}
+
+func (this *ConstraintsFixture) TestExactCount() {
+	constraints := NewConstraints(turnsFor("stare", "steer"))
+	this.So(constraints.ExactCounts['e'], should.Equal, 1)
+	this.So(constraints.Matches("stare"), should.BeTrue)
+	this.So(constraints.Matches("stere"), should.BeFalse)
+}
+
+func (this *ConstraintsFixture) TestMatchesLikeCheckGuess() {
+	random := rand.New(rand.NewSource(1))
+	pick := func() string { return data.ValidTargets[random.Intn(len(data.ValidTargets))] }
+	for range 50 {
+		turns := turnsFor(pick(), pick(), pick(), pick())
+		constraints := NewConstraints(turns)
+		for _, word := range data.ValidTargets {
+			fits := true
+			for _, turn := range turns {
+				fits = fits && CheckGuess(word, turn.Guess) == turn.Pattern
+			}
+			if constraints.Matches(word) != fits {
+				this.Errorf("Matches(%q) = %v for %v", word, !fits, turns)
+				return
+			}
+		}
+	}
+}
+
+func (this *ConstraintsFixture) TestNoTurns() {
+	constraints := NewConstraints(nil)
+	this.So(constraints.Matches("anything"), should.BeTrue)
+	this.So(constraints.String(), should.Equal, "anything")
+}
+
+func (this *ConstraintsFixture) TestMerge() {
+	first := NewConstraints(turnsFor("sheen", "elate"))
+	second := NewConstraints(turnsFor("sheen", "siren"))
+	this.So(first.Merge(second), should.Resemble, NewConstraints(turnsFor("sheen", "elate", "siren")))
+	this.So(first.Merge(NewConstraints(nil)), should.Resemble, first)
+}
+
+func (this *ConstraintsFixture) TestMergeContradictions() {
+	greens := NewConstraints(turnsFor("crane", "crane")).Merge(NewConstraints(turnsFor("brine", "crane")))
+	this.So(greens.Matches("crane"), should.BeFalse)
+	this.So(greens.Matches("brine"), should.BeFalse)
+
+	counts := NewConstraints(turnsFor("stare", "steer")).Merge(NewConstraints(turnsFor("sheen", "steer")))
+	this.So(counts.Matches("stare"), should.BeFalse)
+	this.So(counts.Matches("sheen"), should.BeFalse)
+
+	lengths := NewConstraints(turnsFor("tree", "free")).Merge(NewConstraints(turnsFor("crane", "salet")))
+	this.So(lengths.WordLength, should.Equal, -1)
+	this.So(lengths.Matches("tree"), should.BeFalse)
+	this.So(lengths.Regexp().MatchString("tree"), should.BeFalse)
+}
+
+func (this *ConstraintsFixture) TestString() {
+	this.So(NewConstraints(turnsFor("snake", "slain")).String(), should.Equal, "S?A?? · has N, not 5th · no I, L")
+	this.So(NewConstraints(turnsFor("sheen", "elate", "siren")).String(), should.Equal,
+		"S??EN · has E at least 2 times, not 1st or 5th · no A, I, L, R, T")
+	this.So(NewConstraints(turnsFor("stare", "steer")).String(), should.Equal, "ST??? · has E exactly once, not 3rd or 4th · has R, not 5th")
+	this.So(NewConstraints(turnsFor("eerie", "geese")).String(), should.Equal, "?E??E · has E at least 3 times, not 3rd · no G, S")
+	this.So(NewConstraints(turnsFor("crane", "crane")).String(), should.Equal, "CRANE")
+}
+
+func (this *ConstraintsFixture) TestRegexp() {
+	constraints := NewConstraints(turnsFor("snake", "slain"))
+	this.So(constraints.Regexp().String(), should.Equal, "^s[^il]a[^il][^iln]$")
+	this.So(constraints.Regexp().MatchString("snake"), should.BeTrue)
+	this.So(constraints.Regexp().MatchString("slant"), should.BeFalse)
+	this.So(NewConstraints(turnsFor("ñandú", "ñandu")).Regexp().MatchString("ñandú"), should.BeTrue)
+}
@@ -0,0 +1,284 @@
+package wordle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/tliddle1/wordle/data"
+	"github.com/tliddle1/wordle/pkg/set"
+)
+
+var ErrInvalidDictionary = errors.New("invalid dictionary")
+
+// maxDictionaryErrors is the most problems a dictionary error lists before it only counts the rest
+const maxDictionaryErrors = 10
+
+// Dictionary is the words a game is played with: the targets, and the guesses, which include every target. Every word
+// has the same number of letters, is normalized with NormalizeWord and has nothing but letters, in any alphabet.
+type Dictionary struct {
+	targets    []string
+	guesses    []string
+	guessSet   set.Set[string]
+	wordLength int
+}
+
+// DefaultDictionary returns the dictionary of data.ValidTargets and data.ValidGuesses
+func DefaultDictionary() *Dictionary {
+	dictionary, err := NewDictionary(data.ValidTargets, append(slices.Clone(data.ValidGuesses), data.ValidTargets...))
+	if err != nil {
+		panic(err)
+	}
+	return dictionary
+}
+
+// NewDictionary returns the dictionary of the words, which are normalized like the ones LoadDictionary reads. If
+// guesses is nil, the targets are the only guesses.
+func NewDictionary(targets, guesses []string) (*Dictionary, error) {
+	var guessEntries []wordEntry
+	if guesses != nil {
+		guessEntries = entries(guesses)
+	}
+	return newDictionary("targets", entries(targets), "guesses", guessEntries)
+}
+
+// ReadDictionary reads the targets and guesses from word lists in either format LoadDictionary takes. If guesses is
+// nil, the targets are the only guesses.
+func ReadDictionary(targets, guesses io.Reader) (*Dictionary, error) {
+	targetEntries, err := readWordList(targets)
+	if err != nil {
+		return nil, fmt.Errorf("%w: targets: %w", ErrInvalidDictionary, err)
+	}
+	var guessEntries []wordEntry
+	if guesses != nil {
+		if guessEntries, err = readWordList(guesses); err != nil {
+			return nil, fmt.Errorf("%w: guesses: %w", ErrInvalidDictionary, err)
+		}
+	}
+	return newDictionary("targets", targetEntries, "guesses", guessEntries)
+}
+
+// LoadDictionary reads the targets and guesses from the files at the paths. A file is either a JSON array of strings
+// or has a word on every line, where blank lines and lines starting with # are ignored. Words are normalized with
+// NormalizeWord and deduplicated. Every target has to be one of the guesses. If guessesPath is empty, the targets are the only
+// guesses. Every bad word is reported with its line number.
+func LoadDictionary(targetsPath, guessesPath string) (*Dictionary, error) {
+	targetEntries, err := loadWordList(targetsPath)
+	if err != nil {
+		return nil, err
+	}
+	var guessEntries []wordEntry
+	if guessesPath != "" {
+		if guessEntries, err = loadWordList(guessesPath); err != nil {
+			return nil, err
+		}
+	}
+	return newDictionary(targetsPath, targetEntries, guessesPath, guessEntries)
+}
+
+// Targets returns the targets in the order they were given
+func (this *Dictionary) Targets() []string {
+	return slices.Clone(this.targets)
+}
+
+// Guesses returns the guesses in the order they were given
+func (this *Dictionary) Guesses() []string {
+	return slices.Clone(this.guesses)
+}
+
+// Contains returns true if the word is one of the guesses. It implements WordSet.
+func (this *Dictionary) Contains(word string) bool {
+	return this.guessSet.Contains(word)
+}
+
+// WordLength returns the number of letters every word has
+func (this *Dictionary) WordLength() int {
+	return this.wordLength
+}
+
+// Rules returns DefaultRules with the dictionary's word length
+func (this *Dictionary) Rules() Rules {
+	rules := DefaultRules
+	rules.WordLength = this.wordLength
+	return rules
+}
+
+// private
+
+// wordEntry is a word and the line it was read from
+type wordEntry struct {
+	word string
+	line int
+}
+
+func entries(words []string) []wordEntry {
+	entries := make([]wordEntry, len(words))
+	for i, word := range words {
+		entries[i] = wordEntry{word: word, line: i + 1}
+	}
+	return entries
+}
+
+func loadWordList(path string) ([]wordEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	entries, err := readWordList(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidDictionary, path, err)
+	}
+	return entries, nil
+}
+
+// readWordList reads a JSON array of strings, or a word on every line
+func readWordList(r io.Reader) ([]wordEntry, error) {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(contents); len(trimmed) > 0 && trimmed[0] == '[' {
+		return readJSONWordList(contents)
+	}
+
+	var entries []wordEntry
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" && !strings.HasPrefix(word, "#") {
+			entries = append(entries, wordEntry{word: word, line: line})
+		}
+	}
+	return entries, scanner.Err()
+}
+
+func readJSONWordList(contents []byte) ([]wordEntry, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	var entries []wordEntry
+	for decoder.More() {
+		var word string
+		if err := decoder.Decode(&word); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineOf(contents, decoder.InputOffset()), err)
+		}
+		// The offset is now at the end of the word
+		entries = append(entries, wordEntry{word: word, line: lineOf(contents, decoder.InputOffset())})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// lineOf returns the line the byte at the offset is on
+func lineOf(contents []byte, offset int64) int {
+	return 1 + bytes.Count(contents[:offset], []byte("\n"))
+}
+
+// newDictionary normalizes and validates the words, reporting problems with where the words were read from
+func newDictionary(targetsName string, targets []wordEntry, guessesName string, guesses []wordEntry) (*Dictionary, error) {
+	var problems dictionaryProblems
+	targets = normalize(targets)
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: %s: no words", ErrInvalidDictionary, targetsName)
+	}
+	dictionary := Dictionary{wordLength: NumLetters(targets[0].word), guessSet: set.Set[string]{}}
+	if dictionary.wordLength > MaxWordLength {
+		return nil, fmt.Errorf("%w: %s:%d: \"%s\" has more than %d letters", ErrInvalidDictionary, targetsName, targets[0].line, targets[0].word, MaxWordLength)
+	}
+	targetsAreGuesses := guesses == nil
+	if targetsAreGuesses {
+		guesses, guessesName = targets, targetsName
+	}
+	guesses = normalize(guesses)
+
+	for _, guess := range guesses {
+		if problems.checkWord(guessesName, guess, dictionary.wordLength) {
+			dictionary.guesses = append(dictionary.guesses, guess.word)
+			dictionary.guessSet.Add(guess.word)
+		}
+	}
+	for _, target := range targets {
+		if targetsAreGuesses {
+			if dictionary.guessSet.Contains(target.word) {
+				dictionary.targets = append(dictionary.targets, target.word)
+			}
+			continue
+		}
+		if !problems.checkWord(targetsName, target, dictionary.wordLength) {
+			continue
+		}
+		if !dictionary.guessSet.Contains(target.word) {
+			problems.add("%s:%d: target \"%s\" is not one of the guesses", targetsName, target.line, target.word)
+			continue
+		}
+		dictionary.targets = append(dictionary.targets, target.word)
+	}
+	if err := problems.err(); err != nil {
+		return nil, err
+	}
+	return &dictionary, nil
+}
+
+// normalize normalizes the words and leaves out the ones that were already given
+func normalize(entries []wordEntry) []wordEntry {
+	seen := set.Set[string]{}
+	var normalized []wordEntry
+	for _, entry := range entries {
+		entry.word = NormalizeWord(entry.word)
+		if entry.word == "" || seen.Contains(entry.word) {
+			continue
+		}
+		seen.Add(entry.word)
+		normalized = append(normalized, entry)
+	}
+	return normalized
+}
+
+type dictionaryProblems struct {
+	problems []string
+	count    int
+}
+
+func (this *dictionaryProblems) add(format string, args ...any) {
+	this.count++
+	if len(this.problems) < maxDictionaryErrors {
+		this.problems = append(this.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+// checkWord returns true if the word is fine, and adds a problem if it isn't
+func (this *dictionaryProblems) checkWord(name string, entry wordEntry, wordLength int) bool {
+	for _, letter := range entry.word {
+		if !unicode.IsLetter(letter) {
+			this.add("%s:%d: \"%s\" has %q, which isn't a letter", name, entry.line, entry.word, letter)
+			return false
+		}
+	}
+	if NumLetters(entry.word) != wordLength {
+		this.add("%s:%d: \"%s\" does not have %d letters like the first target", name, entry.line, entry.word, wordLength)
+		return false
+	}
+	return true
+}
+
+func (this *dictionaryProblems) err() error {
+	if this.count == 0 {
+		return nil
+	}
+	message := strings.Join(this.problems, "\n")
+	if this.count > len(this.problems) {
+		message += fmt.Sprintf("\nand %d more", this.count-len(this.problems))
+	}
+	return fmt.Errorf("%w:\n%s", ErrInvalidDictionary, message)
+}
@@ -0,0 +1,105 @@
+package wordle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle/data"
+)
+
+func TestDictionaryFixture(t *testing.T) {
+	gunit.Run(new(DictionaryFixture), t)
+}
+
+type DictionaryFixture struct {
+	*gunit.Fixture
+}
+
+func (this *DictionaryFixture) TestDefault() {
+	dictionary := DefaultDictionary()
+	this.So(dictionary.Targets(), should.Resemble, data.ValidTargets)
+	this.So(dictionary.Guesses(), should.HaveLength, len(data.ValidTargets)+len(data.ValidGuesses))
+	this.So(dictionary.Contains("crane"), should.BeTrue)
+	this.So(dictionary.Contains("xxxxx"), should.BeFalse)
+	this.So(dictionary.Rules(), should.Equal, DefaultRules)
+}
+
+func (this *DictionaryFixture) TestNormalizes() {
+	dictionary, err := NewDictionary([]string{" Crane", "crane", "ANGRY "}, nil)
+	this.So(err, should.BeNil)
+	this.So(dictionary.Targets(), should.Resemble, []string{"crane", "angry"})
+	this.So(dictionary.Guesses(), should.Resemble, []string{"crane", "angry"})
+	this.So(dictionary.WordLength(), should.Equal, 5)
+}
+
+func (this *DictionaryFixture) TestOtherWordLength() {
+	dictionary, err := NewDictionary([]string{"tree", "free"}, []string{"flee", "tree", "free"})
+	this.So(err, should.BeNil)
+	this.So(dictionary.Rules().WordLength, should.Equal, 4)
+	this.So(dictionary.Guesses(), should.Resemble, []string{"flee", "tree", "free"})
+}
+
+func (this *DictionaryFixture) TestReadText() {
+	dictionary, err := ReadDictionary(strings.NewReader("# targets\ncrane\n\nangry\n"), strings.NewReader("angry\r\ncrane\r\nsalet\r\n"))
+	this.So(err, should.BeNil)
+	this.So(dictionary.Targets(), should.Resemble, []string{"crane", "angry"})
+	this.So(dictionary.Guesses(), should.Resemble, []string{"angry", "crane", "salet"})
+}
+
+func (this *DictionaryFixture) TestReadJSON() {
+	dictionary, err := ReadDictionary(strings.NewReader(` ["crane", "Angry"]`), nil)
+	this.So(err, should.BeNil)
+	this.So(dictionary.Targets(), should.Resemble, []string{"crane", "angry"})
+
+	_, err = ReadDictionary(strings.NewReader(`["crane", 5]`), nil)
+	this.So(errors.Is(err, ErrInvalidDictionary), should.BeTrue)
+}
+
+func (this *DictionaryFixture) TestReportsLineNumbers() {
+	_, err := ReadDictionary(strings.NewReader("crane\nangry\ntree\n"), strings.NewReader("[\n\"crane\",\n\"cr4ne\",\n\"tree\"\n]"))
+	this.So(errors.Is(err, ErrInvalidDictionary), should.BeTrue)
+	this.So(err.Error(), should.ContainSubstring, "guesses:3: \"cr4ne\" has '4'")
+	this.So(err.Error(), should.ContainSubstring, "guesses:4: \"tree\" does not have 5 letters")
+	this.So(err.Error(), should.ContainSubstring, "targets:2: target \"angry\" is not one of the guesses")
+	this.So(err.Error(), should.ContainSubstring, "targets:3: \"tree\" does not have 5 letters")
+}
+
+func (this *DictionaryFixture) TestLimitsReportedProblems() {
+	_, err := NewDictionary([]string{"crane", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, nil)
+	this.So(err.Error(), should.EndWith, "and 2 more")
+}
+
+func (this *DictionaryFixture) TestInvalid() {
+	_, err := NewDictionary(nil, nil)
+	this.So(errors.Is(err, ErrInvalidDictionary), should.BeTrue)
+	_, err = NewDictionary([]string{"abcdefghijk"}, nil)
+	this.So(errors.Is(err, ErrInvalidDictionary), should.BeTrue)
+}
+
+func (this *DictionaryFixture) TestLoad() {
+	dir, err := os.MkdirTemp("", "dictionary")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	targets := filepath.Join(dir, "targets.txt")
+	this.So(os.WriteFile(targets, []byte("crane\nangy\n"), 0o600), should.BeNil)
+
+	_, err = LoadDictionary(targets, "")
+	this.So(err.Error(), should.ContainSubstring, targets+":2:")
+	_, err = LoadDictionary(filepath.Join(dir, "missing.txt"), "")
+	this.So(errors.Is(err, os.ErrNotExist), should.BeTrue)
+}
+
+func (this *DictionaryFixture) TestEvaluator() {
+	dictionary, _ := NewDictionary([]string{"tree"}, []string{"tree", "free"})
+	evaluator := NewEvaluator(WithDictionary(dictionary), WithOutput(new(strings.Builder)))
+	this.So(evaluator.Targets(), should.Resemble, []string{"tree"})
+	this.So(evaluator.Rules().WordLength, should.Equal, 4)
+	numGuesses, err := evaluator.PlayGame("tree", NewDummySolverFixedGuesses("free", "tree"))
+	this.So(err, should.BeNil)
+	this.So(numGuesses, should.Equal, 2)
+}
@@ -0,0 +1,464 @@
+package wordle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tliddle1/wordle/data"
+	"github.com/tliddle1/wordle/pkg/set"
+)
+
+type Evaluator struct {
+	validTargetSlice []string
+	validGuessSet    set.Set[string]
+	failFast         bool
+	rules            Rules
+	guessTimeout     time.Duration
+	output           io.Writer
+	renderer         Renderer
+	progress         ProgressFunc
+}
+
+// ProgressFunc is called after each game an evaluation plays with how many of its games have been played so far
+type ProgressFunc func(completed, total int)
+
+type evaluatorConfig struct {
+	targets      []string
+	guesses      []string
+	shuffle      func(n int, swap func(i, j int))
+	sampleSize   int
+	failFast     bool
+	rules        Rules
+	guessTimeout time.Duration
+	output       io.Writer
+	renderer     Renderer
+	progress     ProgressFunc
+	dictionary   *Dictionary
+}
+
+// Option configures an Evaluator
+type Option func(*evaluatorConfig)
+
+// WithSeed makes the order the targets are played in depend only on seed
+func WithSeed(seed int64) Option {
+	return func(config *evaluatorConfig) {
+		config.shuffle = rand.New(rand.NewSource(seed)).Shuffle
+	}
+}
+
+// WithoutShuffle makes the targets be played in the order they were given
+func WithoutShuffle() Option {
+	return func(config *evaluatorConfig) {
+		config.shuffle = nil
+	}
+}
+
+// WithTargets replaces data.ValidTargets as the words a solver will have to find. Targets are always valid guesses.
+func WithTargets(targets []string) Option {
+	return func(config *evaluatorConfig) {
+		config.targets = targets
+	}
+}
+
+// WithGuesses replaces data.ValidGuesses as the words, besides the targets, that a solver is allowed to guess
+func WithGuesses(guesses []string) Option {
+	return func(config *evaluatorConfig) {
+		config.guesses = guesses
+	}
+}
+
+// WithDictionary plays with the dictionary's targets and guesses, and its word length whatever the rules say
+func WithDictionary(dictionary *Dictionary) Option {
+	return func(config *evaluatorConfig) {
+		config.dictionary = dictionary
+	}
+}
+
+// WithSampleSize limits an evaluation to the first n targets after they have been shuffled
+func WithSampleSize(n int) Option {
+	return func(config *evaluatorConfig) {
+		config.sampleSize = n
+	}
+}
+
+// WithFailFast makes an evaluation stop at the first game the solver doesn't win and return why instead of recording
+// the failure in the report and moving on
+func WithFailFast() Option {
+	return func(config *evaluatorConfig) {
+		config.failFast = true
+	}
+}
+
+// WithRules replaces DefaultRules as the rules every game is played with. Words in the target and guess lists that
+// don't have the rules' word length are left out, and since the default lists only have 5 letter words, any other word
+// length needs WithTargets and WithGuesses, or WithDictionary, as well.
+func WithRules(rules Rules) Option {
+	return func(config *evaluatorConfig) {
+		config.rules = rules
+	}
+}
+
+// WithHardMode makes a solver lose a game as soon as it makes a guess that ignores a hint it was given (see
+// CheckHardMode)
+func WithHardMode() Option {
+	return func(config *evaluatorConfig) {
+		config.rules.HardMode = true
+	}
+}
+
+// WithGuessTimeout makes a solver lose a game with a *GuessTimeoutError if it takes longer than timeout to make a
+// guess. The solver can't be used after that, so the evaluation stops there.
+func WithGuessTimeout(timeout time.Duration) Option {
+	return func(config *evaluatorConfig) {
+		config.guessTimeout = timeout
+	}
+}
+
+// WithOutput sends progress and debug output to w instead of stdout
+func WithOutput(w io.Writer) Option {
+	return func(config *evaluatorConfig) {
+		config.output = w
+	}
+}
+
+// WithRenderer draws the turns of the debug output with renderer instead of DefaultRenderer()
+func WithRenderer(renderer Renderer) Option {
+	return func(config *evaluatorConfig) {
+		config.renderer = renderer
+	}
+}
+
+// WithProgress reports progress to progress instead of writing it to the output
+func WithProgress(progress ProgressFunc) Option {
+	return func(config *evaluatorConfig) {
+		config.progress = progress
+	}
+}
+
+func NewEvaluator(options ...Option) *Evaluator {
+	config := evaluatorConfig{
+		targets:  data.ValidTargets,
+		guesses:  data.ValidGuesses,
+		shuffle:  rand.Shuffle,
+		rules:    DefaultRules,
+		output:   os.Stdout,
+		renderer: DefaultRenderer(),
+	}
+	for _, option := range options {
+		option(&config)
+	}
+	if config.dictionary != nil {
+		config.targets = config.dictionary.Targets()
+		config.guesses = config.dictionary.Guesses()
+		config.rules.WordLength = config.dictionary.WordLength()
+	}
+
+	targets := config.rules.filterWords(config.targets)
+	evaluator := Evaluator{
+		validTargetSlice: targets,
+		validGuessSet:    set.Set[string]{},
+		failFast:         config.failFast,
+		rules:            config.rules,
+		guessTimeout:     config.guessTimeout,
+		output:           config.output,
+		renderer:         config.renderer,
+		progress:         config.progress,
+	}
+	for _, guess := range targets {
+		evaluator.validGuessSet.Add(guess)
+	}
+	for _, guess := range config.rules.filterWords(config.guesses) {
+		evaluator.validGuessSet.Add(guess)
+	}
+	if config.shuffle != nil {
+		config.shuffle(len(evaluator.validTargetSlice), func(i, j int) {
+			evaluator.validTargetSlice[i], evaluator.validTargetSlice[j] = evaluator.validTargetSlice[j], evaluator.validTargetSlice[i]
+		})
+	}
+	if config.sampleSize > 0 && config.sampleSize < len(evaluator.validTargetSlice) {
+		evaluator.validTargetSlice = evaluator.validTargetSlice[:config.sampleSize]
+	}
+	if evaluator.progress == nil {
+		evaluator.progress = evaluator.printProgress
+	}
+	return &evaluator
+}
+
+// Rules returns the rules every game is played with
+func (this *Evaluator) Rules() Rules {
+	return this.rules
+}
+
+// Targets returns the targets in the order they will be played
+func (this *Evaluator) Targets() []string {
+	return slices.Clone(this.validTargetSlice)
+}
+
+// EvaluateSolver will play every wordle with the solver and report how it did
+func (this *Evaluator) EvaluateSolver(solver Solver) (*EvaluationReport, error) {
+	return this.EvaluateSolverContext(context.Background(), solver)
+}
+
+// EvaluateSolverContext is EvaluateSolver but stops when ctx is done, returning ctx.Err() with a report of the games
+// that were finished
+func (this *Evaluator) EvaluateSolverContext(ctx context.Context, solver Solver) (*EvaluationReport, error) {
+	return this.EvaluateSolverParallelContext(ctx, func() Solver { return solver }, 1)
+}
+
+// EvaluateSolverParallel will play every wordle on numWorkers goroutines that each use their own solver from newSolver
+// and report how the solvers did. The report and the error returned do not depend on how the goroutines are scheduled.
+func (this *Evaluator) EvaluateSolverParallel(newSolver SolverFactory, numWorkers int) (*EvaluationReport, error) {
+	return this.EvaluateSolverParallelContext(context.Background(), newSolver, numWorkers)
+}
+
+// EvaluateSolverParallelContext is EvaluateSolverParallel but stops when ctx is done, returning ctx.Err() with a
+// report of the games that were finished
+func (this *Evaluator) EvaluateSolverParallelContext(ctx context.Context, newSolver SolverFactory, numWorkers int) (*EvaluationReport, error) {
+	if err := this.rules.Validate(); err != nil {
+		return newEvaluationReport(nil), err
+	}
+	targets := this.validTargetSlice
+	solvers := []Solver{newSolver()}
+	debug := solvers[0].Debug()
+	if debug && len(targets) > 0 {
+		targets = targets[:1]
+	} else if !debug {
+		for len(solvers) < numWorkers {
+			solvers = append(solvers, newSolver())
+		}
+	}
+
+	games := make([]GameResult, len(targets))
+	played := make([]bool, len(targets))
+	progress := progressCounter{total: len(targets), report: this.progress}
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for _, solver := range solvers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				solver.Reset()
+				if debug {
+					fmt.Fprintln(this.output, "target:", targets[i])
+				}
+				game := this.playGame(ctx, targets[i], solver)
+				if ctx.Err() != nil && errors.Is(game.Err, ctx.Err()) {
+					continue
+				}
+				games[i], played[i] = game, true
+				progress.increment()
+				if this.endsEvaluation(game) {
+					stopOnce.Do(func() { close(stop) })
+				}
+				if errors.Is(game.Err, ErrGuessTimeout) {
+					return // the solver might still be working on its guess, so it can't be used again
+				}
+			}
+		}()
+	}
+	// Targets are handed out in order and nothing new is handed out after a game that ends the evaluation, so every
+	// target before the first such game has been played by the time the workers are done.
+dispatch:
+	for i := range targets {
+		select {
+		case jobs <- i:
+		case <-stop:
+			break dispatch
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		var finished []GameResult
+		for i, game := range games {
+			if played[i] {
+				finished = append(finished, game)
+			}
+		}
+		return newEvaluationReport(finished), err
+	}
+	for i, game := range games {
+		if this.endsEvaluation(game) {
+			return newEvaluationReport(games[:i+1]), game.Err
+		}
+	}
+	return newEvaluationReport(games), nil
+}
+
+// endsEvaluation returns true if no more games should be played after this one
+func (this *Evaluator) endsEvaluation(game GameResult) bool {
+	return (this.failFast && game.Err != nil) || errors.Is(game.Err, ErrGuessTimeout)
+}
+
+// PlayGame will simulate a single game of wordle
+func (this *Evaluator) PlayGame(target string, solver Solver) (int, error) {
+	return this.PlayGameContext(context.Background(), target, solver)
+}
+
+// PlayGameContext is PlayGame but gives up when ctx is done, returning ctx.Err()
+func (this *Evaluator) PlayGameContext(ctx context.Context, target string, solver Solver) (int, error) {
+	game := this.playGame(ctx, target, solver)
+	if game.Err != nil && !errors.Is(game.Err, ErrLostGame) {
+		return -1, game.Err
+	}
+	return game.NumGuesses, game.Err
+}
+
+// PlayAdversarialGame will simulate a game against an Adversary that starts with every target as a candidate. There
+// is no guess limit other than the number of targets, which a solver that only guesses candidates can't reach. The
+// result's target is the word the adversary was left with.
+func (this *Evaluator) PlayAdversarialGame(solver Solver) GameResult {
+	return this.PlayAdversarialGameContext(context.Background(), solver)
+}
+
+// PlayAdversarialGameContext is PlayAdversarialGame but gives up when ctx is done, with ctx.Err() as the result's error
+func (this *Evaluator) PlayAdversarialGameContext(ctx context.Context, solver Solver) GameResult {
+	solver.Reset()
+	adversary := NewAdversary(this.validTargetSlice)
+	game, err := NewAdversarialGame(adversary, this.validGuessSet, this.rules)
+	if err != nil {
+		return GameResult{Target: adversary.target(), Err: err}
+	}
+	return this.play(ctx, game, solver)
+}
+
+func (this *Evaluator) playGame(ctx context.Context, target string, solver Solver) GameResult {
+	game, err := NewGame(target, this.validGuessSet, this.rules)
+	if err != nil {
+		return GameResult{Target: target, Err: err}
+	}
+	return this.play(ctx, game, solver)
+}
+
+// host answers the guesses in a game
+type host interface {
+	Respond(guess string) Pattern
+	// target returns the target, or the word the host would reveal as the target if the game ended now
+	target() string
+}
+
+type fixedTarget string
+
+func (this fixedTarget) Respond(guess string) Pattern {
+	return CheckGuess(string(this), guess)
+}
+
+func (this fixedTarget) target() string {
+	return string(this)
+}
+
+// play has the solver play the game until it is over, or until a guess isn't allowed, which ends it with that error
+func (this *Evaluator) play(ctx context.Context, game *Game, solver Solver) GameResult {
+	debug := solver.Debug()
+	for game.Status() == GameInProgress {
+		if err := ctx.Err(); err != nil {
+			return this.endGame(game, err)
+		}
+		turns := game.Turns()
+		guess, err := this.askForGuess(ctx, func() string { return solver.Guess(turns) })
+		if err == nil {
+			err = solverErr(solver)
+		}
+		if errors.Is(err, ErrGuessTimeout) {
+			err = &GuessTimeoutError{Target: game.Target(), Turn: len(turns) + 1, Timeout: this.guessTimeout}
+		}
+		if err != nil {
+			return this.endGame(game, err)
+		}
+		pattern, err := game.Submit(guess)
+		if err != nil {
+			return this.endGame(game, err)
+		}
+		if debug {
+			this.renderer.RenderTurn(this.output, Turn{guess, pattern})
+		}
+	}
+	result := game.Result()
+	if debug && result.Won() {
+		fmt.Fprintln(this.output, result.NumGuesses, "guesses")
+	} else if debug {
+		fmt.Fprintf(this.output, "The word was: %s\n", result.Target)
+	}
+	return result
+}
+
+// endGame returns the result of a game that was ended early by err
+func (this *Evaluator) endGame(game *Game, err error) GameResult {
+	result := game.Result()
+	result.Err = err
+	return result
+}
+
+// solverErr returns the error the solver reports for its last guess if it is an ErrorReporter
+func solverErr(solver any) error {
+	if reporter, ok := solver.(ErrorReporter); ok {
+		return reporter.Err()
+	}
+	return nil
+}
+
+// validateGuess returns an error if the guess is not allowed no matter what the turn history is
+func (this *Evaluator) validateGuess(guess string) error {
+	return validateWord(guess, this.rules.WordLength, this.validGuessSet)
+}
+
+// askForGuess returns the result of guess, or ErrGuessTimeout if guess takes longer than the guess timeout or ctx.Err()
+// if ctx is done first. A solver that doesn't answer in time is left running in the background.
+func (this *Evaluator) askForGuess(ctx context.Context, guess func() string) (string, error) {
+	if this.guessTimeout <= 0 && ctx.Done() == nil {
+		return guess(), nil
+	}
+	guesses := make(chan string, 1)
+	go func() {
+		guesses <- guess()
+	}()
+
+	var timeout <-chan time.Time
+	if this.guessTimeout > 0 {
+		timer := time.NewTimer(this.guessTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case guess := <-guesses:
+		return guess, nil
+	case <-timeout:
+		return "", ErrGuessTimeout
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (this *Evaluator) printProgress(completed, total int) {
+	if completed%50 == 0 {
+		fmt.Fprintf(this.output, "%d/%d completed\n", completed, total)
+	}
+}
+
+// progressCounter serializes the progress reports of concurrent games
+type progressCounter struct {
+	mu        sync.Mutex
+	completed int
+	total     int
+	report    ProgressFunc
+}
+
+func (this *progressCounter) increment() {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.completed++
+	this.report(this.completed, this.total)
+}
@@ -0,0 +1,211 @@
+package wordle
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// WordSet is the set of words a Game accepts as guesses, like a set.Set[string]
+type WordSet interface {
+	Contains(word string) bool
+}
+
+// GameStatus is whether a Game is still being played and, if not, how it ended
+type GameStatus uint8
+
+const (
+	GameInProgress GameStatus = iota
+	GameWon
+	GameLost
+)
+
+var gameStatusNames = [...]string{
+	GameInProgress: "in progress",
+	GameWon:        "won",
+	GameLost:       "lost",
+}
+
+func (this GameStatus) String() string {
+	if int(this) < len(gameStatusNames) {
+		return gameStatusNames[this]
+	}
+	return fmt.Sprintf("GameStatus(%d)", uint8(this))
+}
+
+// MarshalText writes the status as it is printed, like "in progress"
+func (this GameStatus) MarshalText() ([]byte, error) {
+	if int(this) >= len(gameStatusNames) {
+		return nil, fmt.Errorf("invalid game status %d", uint8(this))
+	}
+	return []byte(this.String()), nil
+}
+
+// UnmarshalText reads a status written by MarshalText
+func (this *GameStatus) UnmarshalText(text []byte) error {
+	for status, name := range gameStatusNames {
+		if string(text) == name {
+			*this = GameStatus(status)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid game status \"%s\"", text)
+}
+
+// Game is a single game of wordle being played. Guesses are submitted one at a time and are checked against the rules
+// before they count as a turn.
+type Game struct {
+	host   host
+	words  WordSet
+	rules  Rules
+	turns  []Turn
+	status GameStatus
+}
+
+// NewGame starts a game with the target. Guesses have to be in words, or only have to have the right length if words
+// is nil. The target does not have to be in words.
+func NewGame(target string, words WordSet, rules Rules) (*Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if NumLetters(target) != rules.WordLength {
+		return nil, fmt.Errorf("%w: \"%s\" does not have %d letters", ErrInvalidTarget, target, rules.WordLength)
+	}
+	return newGame(fixedTarget(target), words, rules), nil
+}
+
+// NewAdversarialGame starts a game against the adversary. The guess limit is raised to the number of candidates the
+// adversary has, which a player who only guesses candidates can't reach.
+func NewAdversarialGame(adversary *Adversary, words WordSet, rules Rules) (*Game, error) {
+	rules.MaxNumGuesses = max(rules.MaxNumGuesses, len(adversary.Candidates()))
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return newGame(adversary, words, rules), nil
+}
+
+func newGame(host host, words WordSet, rules Rules) *Game {
+	return &Game{host: host, words: words, rules: rules}
+}
+
+// Target returns the target. Against an adversary it is the word the adversary would reveal if the game ended now.
+func (this *Game) Target() string {
+	return this.host.target()
+}
+
+// Rules returns the rules the game is played by
+func (this *Game) Rules() Rules {
+	return this.rules
+}
+
+// Turns returns every guess that counted with its pattern
+func (this *Game) Turns() []Turn {
+	return slices.Clone(this.turns)
+}
+
+// Status returns whether the game is in progress, won or lost
+func (this *Game) Status() GameStatus {
+	return this.status
+}
+
+// GuessesLeft returns how many more guesses can be submitted
+func (this *Game) GuessesLeft() int {
+	if this.status != GameInProgress {
+		return 0
+	}
+	return this.rules.MaxNumGuesses - len(this.turns)
+}
+
+// Submit plays the guess and returns its pattern. If the guess isn't allowed it doesn't count as a turn and the error
+// wraps ErrInvalidLengthGuess or ErrInvalidGuess, or is a *HardModeError. If the game is over the error wraps
+// ErrGameOver. Running out of guesses is not an error, it makes the status GameLost.
+func (this *Game) Submit(guess string) (Pattern, error) {
+	if this.status != GameInProgress {
+		return Pattern{}, fmt.Errorf("%w: the game was %s", ErrGameOver, this.status)
+	}
+	if err := validateWord(guess, this.rules.WordLength, this.words); err != nil {
+		return Pattern{}, err
+	}
+	if this.rules.HardMode {
+		if err := CheckHardMode(this.turns, guess); err != nil {
+			return Pattern{}, err
+		}
+	}
+
+	pattern := this.host.Respond(guess)
+	this.turns = append(this.turns, Turn{guess, pattern})
+	if pattern.IsCorrect() {
+		this.status = GameWon
+	} else if len(this.turns) >= this.rules.MaxNumGuesses {
+		this.status = GameLost
+	}
+	return pattern, nil
+}
+
+// Result returns the game as a GameResult. Its error wraps ErrLostGame if the game was lost and is nil otherwise.
+func (this *Game) Result() GameResult {
+	result := GameResult{Target: this.Target(), NumGuesses: len(this.turns), Turns: this.Turns()}
+	if this.status == GameLost {
+		result.Err = fmt.Errorf("%w: %s", ErrLostGame, result.Target)
+	}
+	return result
+}
+
+type gameJSON struct {
+	Target string     `json:"target"`
+	Rules  Rules      `json:"rules"`
+	Turns  []Turn     `json:"turns"`
+	Status GameStatus `json:"status"`
+}
+
+// MarshalJSON writes the target, rules, turns and status. A game against an adversary is written with the target it
+// would reveal now, so it reads back as a game with that target.
+func (this *Game) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gameJSON{
+		Target: this.Target(),
+		Rules:  this.rules,
+		Turns:  this.turns,
+		Status: this.status,
+	})
+}
+
+// UnmarshalJSON reads a game written by MarshalJSON. The words guesses are checked against aren't written, so they
+// are kept from the game being read into: a game from NewGame keeps its words, a zero Game accepts any word. The
+// turns have to be a game that could have been played with the target and rules, and the status has to follow from
+// them.
+func (this *Game) UnmarshalJSON(data []byte) error {
+	var saved gameJSON
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	game, err := NewGame(saved.Target, nil, saved.Rules)
+	if err != nil {
+		return err
+	}
+	for i, turn := range saved.Turns {
+		pattern, err := game.Submit(turn.Guess)
+		if err != nil {
+			return fmt.Errorf("%w: turn %d: %w", ErrInvalidGame, i+1, err)
+		}
+		if pattern != turn.Pattern {
+			return fmt.Errorf("%w: turn %d does not have the pattern \"%s\" gets", ErrInvalidGame, i+1, turn.Guess)
+		}
+	}
+	if game.status != saved.Status {
+		return fmt.Errorf("%w: the status is %s but the turns make it %s", ErrInvalidGame, saved.Status, game.status)
+	}
+	game.words = this.words
+	*this = *game
+	return nil
+}
+
+// validateWord returns an error if the guess is not allowed no matter what the turn history is
+func validateWord(guess string, wordLength int, words WordSet) error {
+	if NumLetters(guess) != wordLength {
+		return fmt.Errorf("%w: \"%s\"", ErrInvalidLengthGuess, guess)
+	}
+	if words != nil && !words.Contains(guess) {
+		return fmt.Errorf("%w: \"%s\"", ErrInvalidGuess, guess)
+	}
+	return nil
+}
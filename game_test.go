@@ -0,0 +1,139 @@
+package wordle
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle/pkg/set"
+)
+
+func TestGameFixture(t *testing.T) {
+	gunit.Run(new(GameFixture), t)
+}
+
+type GameFixture struct {
+	*gunit.Fixture
+	words set.Set[string]
+}
+
+func (this *GameFixture) Setup() {
+	this.words = set.Set[string]{}
+	for _, word := range []string{"crane", "crank", "drank", "prank", "angry", "salet"} {
+		this.words.Add(word)
+	}
+}
+
+func (this *GameFixture) newGame(target string, rules Rules) *Game {
+	game, err := NewGame(target, this.words, rules)
+	this.So(err, should.BeNil)
+	return game
+}
+
+func (this *GameFixture) TestWin() {
+	game := this.newGame("crank", DefaultRules)
+	pattern, err := game.Submit("crane")
+	this.So(err, should.BeNil)
+	this.So(pattern, should.Equal, Pattern{Green, Green, Green, Green, Gray})
+	this.So(game.Status(), should.Equal, GameInProgress)
+	this.So(game.GuessesLeft(), should.Equal, 5)
+
+	pattern, err = game.Submit("crank")
+	this.So(err, should.BeNil)
+	this.So(pattern.IsCorrect(), should.BeTrue)
+	this.So(game.Status(), should.Equal, GameWon)
+	this.So(game.GuessesLeft(), should.Equal, 0)
+	this.So(game.Result(), should.Resemble, GameResult{
+		Target:     "crank",
+		NumGuesses: 2,
+		Turns:      []Turn{{"crane", CheckGuess("crank", "crane")}, {"crank", CorrectPattern}},
+	})
+}
+
+func (this *GameFixture) TestLose() {
+	game := this.newGame("crank", Rules{WordLength: 5, MaxNumGuesses: 2})
+	game.Submit("salet")
+	game.Submit("angry")
+	this.So(game.Status(), should.Equal, GameLost)
+	this.So(errors.Is(game.Result().Err, ErrLostGame), should.BeTrue)
+
+	_, err := game.Submit("crank")
+	this.So(errors.Is(err, ErrGameOver), should.BeTrue)
+	this.So(game.Turns(), should.HaveLength, 2)
+}
+
+func (this *GameFixture) TestInvalidGuessesDoNotCount() {
+	game := this.newGame("crank", DefaultRules)
+	_, err := game.Submit("cranes")
+	this.So(errors.Is(err, ErrInvalidLengthGuess), should.BeTrue)
+	_, err = game.Submit("xxxxx")
+	this.So(errors.Is(err, ErrInvalidGuess), should.BeTrue)
+	this.So(game.Turns(), should.BeEmpty)
+	this.So(game.GuessesLeft(), should.Equal, 6)
+}
+
+func (this *GameFixture) TestAnyWordWithoutWords() {
+	game, err := NewGame("crank", nil, DefaultRules)
+	this.So(err, should.BeNil)
+	_, err = game.Submit("xxxxx")
+	this.So(err, should.BeNil)
+}
+
+func (this *GameFixture) TestHardMode() {
+	game := this.newGame("crank", Rules{WordLength: 5, MaxNumGuesses: 6, HardMode: true})
+	game.Submit("crane")
+	_, err := game.Submit("salet")
+	var hardModeErr *HardModeError
+	this.So(errors.As(err, &hardModeErr), should.BeTrue)
+	this.So(game.Turns(), should.HaveLength, 1)
+	_, err = game.Submit("crank")
+	this.So(err, should.BeNil)
+}
+
+func (this *GameFixture) TestInvalidNewGame() {
+	_, err := NewGame("crank", nil, Rules{WordLength: 5})
+	this.So(errors.Is(err, ErrInvalidRules), should.BeTrue)
+	_, err = NewGame("cranks", nil, DefaultRules)
+	this.So(errors.Is(err, ErrInvalidTarget), should.BeTrue)
+}
+
+func (this *GameFixture) TestAdversarialGame() {
+	adversary := NewAdversary([]string{"angry", "crane", "crank", "drank", "prank"})
+	game, err := NewAdversarialGame(adversary, this.words, Rules{WordLength: 5, MaxNumGuesses: 1})
+	this.So(err, should.BeNil)
+	this.So(game.GuessesLeft(), should.Equal, 5)
+	game.Submit("crank")
+	this.So(game.Target(), should.Equal, "drank")
+}
+
+func (this *GameFixture) TestJSONRoundTrip() {
+	game := this.newGame("crank", DefaultRules)
+	game.Submit("crane")
+	data, err := json.Marshal(game)
+	this.So(err, should.BeNil)
+	this.So(string(data), should.Equal, `{"target":"crank","rules":{"wordLength":5,"maxNumGuesses":6,"hardMode":false},`+
+		`"turns":[{"guess":"crane","pattern":"GGGG."}],"status":"in progress"}`)
+
+	restored := this.newGame("angry", DefaultRules)
+	this.So(json.Unmarshal(data, restored), should.BeNil)
+	this.So(restored.Target(), should.Equal, "crank")
+	this.So(restored.Turns(), should.Resemble, game.Turns())
+	_, err = restored.Submit("xxxxx")
+	this.So(errors.Is(err, ErrInvalidGuess), should.BeTrue)
+	_, err = restored.Submit("crank")
+	this.So(err, should.BeNil)
+	this.So(restored.Status(), should.Equal, GameWon)
+}
+
+func (this *GameFixture) TestUnmarshalRejectsInconsistentGames() {
+	for _, data := range []string{
+		`{"target":"crank","rules":{"wordLength":5,"maxNumGuesses":6},"turns":[{"guess":"crane","pattern":"GGGGG"}],"status":"in progress"}`,
+		`{"target":"crank","rules":{"wordLength":5,"maxNumGuesses":6},"turns":[{"guess":"crane","pattern":"GGGG."}],"status":"won"}`,
+		`{"target":"crank","rules":{"wordLength":5,"maxNumGuesses":1},"turns":[{"guess":"crane","pattern":"GGGG."},{"guess":"crank","pattern":"GGGGG"}],"status":"lost"}`,
+	} {
+		var game Game
+		this.So(errors.Is(json.Unmarshal([]byte(data), &game), ErrInvalidGame), should.BeTrue)
+	}
+}
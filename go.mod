@@ -5,4 +5,5 @@ go 1.22.6
 require (
 	github.com/smarty/assertions v1.16.0
 	github.com/smarty/gunit v1.5.0
+	golang.org/x/text v0.21.0
 )
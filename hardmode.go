@@ -0,0 +1,69 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HardModeError describes the hint a guess ignored in hard mode
+type HardModeError struct {
+	Guess string
+	Hint  string // what the guess had to do, like "5th letter must be E"
+}
+
+func (this *HardModeError) Error() string {
+	return fmt.Sprintf("%s: \"%s\": %s", ErrHardModeViolation, this.Guess, this.Hint)
+}
+
+func (this *HardModeError) Unwrap() error {
+	return ErrHardModeViolation
+}
+
+// CheckHardMode returns a *HardModeError wrapping ErrHardModeViolation if the guess ignores a hint from the turn history. In
+// hard mode every green letter has to stay where it is and every yellow letter has to be used again.
+func CheckHardMode(turnHistory []Turn, guess string) error {
+	return NewConstraints(turnHistory).CheckHardMode(guess)
+}
+
+// CheckHardMode is like the function of the same name for the turn history the constraints are from, for checking many
+// guesses against the same history
+func (this Constraints) CheckHardMode(guess string) error {
+	guessLetters := []rune(guess)
+	for i, letter := range this.Greens {
+		if letter != 0 && (i >= len(guessLetters) || guessLetters[i] != letter) {
+			return &HardModeError{Guess: guess, Hint: fmt.Sprintf("%s letter must be %s", ordinal(i+1), upper(letter))}
+		}
+	}
+	guessCounts := letterCounts(guessLetters)
+	for _, letter := range this.Present() {
+		if guessCounts[letter] < this.MinCounts[letter] {
+			return &HardModeError{Guess: guess, Hint: "guess must contain " + upper(letter)}
+		}
+	}
+	return nil
+}
+
+func letterCounts(letters []rune) map[rune]int {
+	counts := make(map[rune]int)
+	for _, letter := range letters {
+		counts[letter]++
+	}
+	return counts
+}
+
+func upper(letter rune) string {
+	return strings.ToUpper(string(letter))
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
@@ -0,0 +1,46 @@
+package wordle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckHardMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		turnHistory []Turn
+		guess       string
+		expected    string
+	}{
+		{name: "no history", turnHistory: nil, guess: "salet"},
+		{name: "uses every hint", turnHistory: []Turn{{Guess: "slain", Pattern: Pattern{Green, Gray, Green, Gray, Yellow}}}, guess: "snake"},
+		{name: "moved green", turnHistory: []Turn{{Guess: "slain", Pattern: Pattern{Green, Gray, Green, Gray, Yellow}}}, guess: "nasty", expected: "1st letter must be S"},
+		{name: "missing yellow", turnHistory: []Turn{{Guess: "slain", Pattern: Pattern{Green, Gray, Green, Gray, Yellow}}}, guess: "shake", expected: "guess must contain N"},
+		{name: "green from earlier turn", turnHistory: []Turn{
+			{Guess: "crane", Pattern: Pattern{Gray, Gray, Gray, Gray, Green}},
+			{Guess: "lousy", Pattern: Pattern{Gray, Gray, Gray, Yellow, Gray}},
+		}, guess: "spots", expected: "5th letter must be E"},
+		{name: "repeated yellow letter", turnHistory: []Turn{{Guess: "elate", Pattern: Pattern{Yellow, Gray, Gray, Gray, Yellow}}}, guess: "sheik", expected: "guess must contain E"},
+		{name: "repeated yellow letter used twice", turnHistory: []Turn{{Guess: "elate", Pattern: Pattern{Yellow, Gray, Gray, Gray, Yellow}}}, guess: "sheen"},
+		{name: "gray letters may be reused", turnHistory: []Turn{{Guess: "salet", Pattern: Pattern{Gray, Gray, Gray, Gray, Gray}}}, guess: "salty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckHardMode(tt.turnHistory, tt.guess)
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("CheckHardMode returned %v when no error was expected for guess: %s", err, tt.guess)
+				}
+				return
+			}
+			if !errors.Is(err, ErrHardModeViolation) {
+				t.Fatalf("CheckHardMode returned %v when %s was expected for guess: %s", err, ErrHardModeViolation, tt.guess)
+			}
+			var hardModeErr *HardModeError
+			if !errors.As(err, &hardModeErr) || hardModeErr.Hint != tt.expected {
+				t.Errorf("CheckHardMode returned %q when %q was expected for guess: %s", err, tt.expected, tt.guess)
+			}
+		})
+	}
+}
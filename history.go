@@ -0,0 +1,183 @@
+package wordle
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// maxExhaustiveTurns is the most turns ValidateHistory tries every combination of to find the fewest that conflict
+const maxExhaustiveTurns = 12
+
+// Tile is a letter of a guess in the turn history, which has the color at its position in the turn's pattern
+type Tile struct {
+	Turn     int // the index of the turn
+	Position int // the index of the letter in the guess
+}
+
+// InconsistentHistoryError says which turns no target fits, like when a color was typed in wrong
+type InconsistentHistoryError struct {
+	History []Turn
+	Turns   []int  // the indices of the fewest turns no target fits together
+	Tiles   []Tile // tiles of those turns that can't all have the colors they have, none of which can be left out
+}
+
+func (this *InconsistentHistoryError) Error() string {
+	if len(this.Turns) == 0 {
+		return fmt.Sprintf("%s: there are no targets", ErrInconsistentHistory)
+	}
+	turns := make([]string, len(this.Turns))
+	for i, turn := range this.Turns {
+		turns[i] = fmt.Sprintf("%d (%s)", turn+1, this.History[turn].Guess)
+	}
+	tiles := make([]string, len(this.Tiles))
+	for i, tile := range this.Tiles {
+		tiles[i] = fmt.Sprintf("%s letter in turn %d", ordinal(tile.Position+1), tile.Turn+1)
+		if letters := []rune(this.History[tile.Turn].Guess); tile.Position < len(letters) {
+			tiles[i] = fmt.Sprintf("%s %s in turn %d", ordinal(tile.Position+1), upper(letters[tile.Position]), tile.Turn+1)
+		}
+	}
+	return fmt.Sprintf("%s: no target fits turns %s, check the colors of the %s",
+		ErrInconsistentHistory, strings.Join(turns, ", "), strings.Join(tiles, ", "))
+}
+
+func (this *InconsistentHistoryError) Unwrap() error {
+	return ErrInconsistentHistory
+}
+
+// ValidateHistory returns nil if at least one of the targets would have given every pattern in the turn history, and
+// otherwise an *InconsistentHistoryError wrapping ErrInconsistentHistory. The fewest turns that conflict are found by
+// trying every combination of them for histories of up to 12 turns, and by leaving out one turn at a time for longer
+// ones.
+func ValidateHistory(turnHistory []Turn, targets []string) error {
+	patterns := make([][]Pattern, len(targets))
+	for i, target := range targets {
+		patterns[i] = make([]Pattern, len(turnHistory))
+		for j, turn := range turnHistory {
+			patterns[i][j] = CheckGuess(target, turn.Guess)
+		}
+	}
+	history := historyCheck{turnHistory: turnHistory, patterns: patterns, ignored: make([]Pattern, len(turnHistory))}
+	all := make([]bool, len(turnHistory))
+	for i := range all {
+		all[i] = true
+	}
+	if history.fits(all) {
+		return nil
+	}
+	turns := history.fewestConflictingTurns()
+	return &InconsistentHistoryError{History: turnHistory, Turns: turns, Tiles: history.conflictingTiles(turns)}
+}
+
+// historyCheck is what ValidateHistory checks targets against the turns with
+type historyCheck struct {
+	turnHistory []Turn
+	patterns    [][]Pattern // the pattern every guess gives against every target
+	ignored     []Pattern   // the tiles of every turn that are left out, marked with a nonzero color
+}
+
+// fits returns true if a target gives the patterns of the included turns, other than at the ignored tiles
+func (this *historyCheck) fits(included []bool) bool {
+	for _, patterns := range this.patterns {
+		if this.targetFits(patterns, included) {
+			return true
+		}
+	}
+	return false
+}
+
+func (this *historyCheck) targetFits(patterns []Pattern, included []bool) bool {
+	for i, turn := range this.turnHistory {
+		if !included[i] {
+			continue
+		}
+		for j, color := range turn.Pattern {
+			if this.ignored[i][j] == 0 && patterns[i][j] != color {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fewestConflictingTurns returns the indices of the fewest turns no target fits together
+func (this *historyCheck) fewestConflictingTurns() []int {
+	numTurns := len(this.turnHistory)
+	if numTurns > maxExhaustiveTurns {
+		return this.leaveOutTurns()
+	}
+	// A combination of turns conflicts if every target fails one of them, so only the sets of turns targets fail matter
+	failures := make(map[uint32]bool)
+	for _, patterns := range this.patterns {
+		var failed uint32
+		for i, turn := range this.turnHistory {
+			if patterns[i] != turn.Pattern {
+				failed |= 1 << i
+			}
+		}
+		failures[failed] = true
+	}
+	best := uint32(1)<<numTurns - 1
+	for combination := uint32(0); combination < 1<<numTurns; combination++ {
+		if bits.OnesCount32(combination) >= bits.OnesCount32(best) {
+			continue
+		}
+		conflicts := true
+		for failed := range failures {
+			if failed&combination == 0 {
+				conflicts = false
+				break
+			}
+		}
+		if conflicts {
+			best = combination
+		}
+	}
+	var turns []int
+	for i := range numTurns {
+		if best&(1<<i) != 0 {
+			turns = append(turns, i)
+		}
+	}
+	return turns
+}
+
+// leaveOutTurns returns the indices of turns no target fits together, none of which can be left out
+func (this *historyCheck) leaveOutTurns() []int {
+	included := make([]bool, len(this.turnHistory))
+	for i := range included {
+		included[i] = true
+	}
+	for i := range included {
+		included[i] = false
+		if this.fits(included) {
+			included[i] = true
+		}
+	}
+	var turns []int
+	for i, isIncluded := range included {
+		if isIncluded {
+			turns = append(turns, i)
+		}
+	}
+	return turns
+}
+
+// conflictingTiles returns tiles of the turns no target fits together, none of which can be left out
+func (this *historyCheck) conflictingTiles(turns []int) []Tile {
+	included := make([]bool, len(this.turnHistory))
+	for _, turn := range turns {
+		included[turn] = true
+	}
+	var tiles []Tile
+	for _, turn := range turns {
+		for position := range this.turnHistory[turn].Pattern.Len() {
+			this.ignored[turn][position] = Gray
+			if this.fits(included) {
+				this.ignored[turn][position] = 0
+				tiles = append(tiles, Tile{Turn: turn, Position: position})
+			}
+		}
+	}
+	return tiles
+}
@@ -0,0 +1,72 @@
+package wordle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle/data"
+)
+
+func TestHistoryFixture(t *testing.T) {
+	gunit.Run(new(HistoryFixture), t)
+}
+
+type HistoryFixture struct {
+	*gunit.Fixture
+}
+
+func (this *HistoryFixture) validate(turns []Turn) *InconsistentHistoryError {
+	err := ValidateHistory(turns, data.ValidTargets)
+	this.So(errors.Is(err, ErrInconsistentHistory), should.BeTrue)
+	var inconsistent *InconsistentHistoryError
+	this.So(errors.As(err, &inconsistent), should.BeTrue)
+	return inconsistent
+}
+
+func (this *HistoryFixture) TestConsistent() {
+	this.So(ValidateHistory(nil, data.ValidTargets), should.BeNil)
+	this.So(ValidateHistory(turnsFor("sheen", "soare", "elate", "siren"), data.ValidTargets), should.BeNil)
+}
+
+func (this *HistoryFixture) TestMistypedColor() {
+	turns := turnsFor("sheen", "soare", "elate", "siren")
+	turns[2].Pattern[3] = Yellow // the E of "siren" was green
+	err := this.validate(turns)
+	this.So(err.Turns, should.Resemble, []int{0, 2})
+	this.So(err.Tiles, should.Contain, Tile{Turn: 2, Position: 3})
+	this.So(err.Error(), should.StartWith, "no target fits the turn history: no target fits turns 1 (soare), 3 (siren), check the colors of the ")
+}
+
+func (this *HistoryFixture) TestSingleTurn() {
+	turns := turnsFor("sheen", "soare", "elate")
+	turns = append(turns, Turn{Guess: "xylyl", Pattern: CorrectPattern})
+	err := this.validate(turns)
+	this.So(err.Turns, should.Resemble, []int{2})
+	this.So(err.Tiles, should.Resemble, []Tile{{Turn: 2, Position: 2}, {Turn: 2, Position: 4}})
+}
+
+func (this *HistoryFixture) TestImpossiblePattern() {
+	// CheckGuess makes the first E yellow before the second one
+	err := this.validate([]Turn{{Guess: "sheen", Pattern: Pattern{Gray, Gray, Gray, Yellow, Gray}}})
+	this.So(err.Turns, should.Resemble, []int{0})
+	this.So(err.Tiles, should.Resemble, []Tile{{Turn: 0, Position: 2}, {Turn: 0, Position: 3}})
+	this.So(err.Error(), should.EndWith, "check the colors of the 3rd E in turn 1, 4th E in turn 1")
+}
+
+func (this *HistoryFixture) TestNoTargets() {
+	err := ValidateHistory(turnsFor("sheen", "soare"), nil)
+	this.So(err.(*InconsistentHistoryError).Turns, should.BeEmpty)
+	this.So(err.Error(), should.EndWith, "there are no targets")
+}
+
+func (this *HistoryFixture) TestLongHistory() {
+	var turns []Turn
+	for range maxExhaustiveTurns {
+		turns = append(turns, turnsFor("sheen", "crane")...)
+	}
+	turns = append(turns, Turn{Guess: "crane", Pattern: CorrectPattern})
+	err := this.validate(turns)
+	this.So(err.Turns, should.Resemble, []int{maxExhaustiveTurns - 1, maxExhaustiveTurns})
+}
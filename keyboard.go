@@ -0,0 +1,84 @@
+package wordle
+
+import (
+	"io"
+	"slices"
+	"strings"
+)
+
+// QWERTY is the layout of the letters on an English keyboard, by row
+var QWERTY = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// Keyboard is the best color every guessed letter has had: green if it was ever green, yellow if it was ever yellow,
+// and gray if it was only ever gray. Letters that weren't guessed aren't in it.
+type Keyboard map[rune]LetterColor
+
+// NewKeyboard returns the keyboard for the turn history
+func NewKeyboard(turnHistory []Turn) Keyboard {
+	keyboard := make(Keyboard)
+	for _, turn := range turnHistory {
+		keyboard.Update(turn)
+	}
+	return keyboard
+}
+
+// Update adds the colors of the turn
+func (this Keyboard) Update(turn Turn) {
+	colors := turn.Pattern.Colors()
+	for i, letter := range []rune(turn.Guess) {
+		if i < len(colors) {
+			this[letter] = max(this[letter], colors[i])
+		}
+	}
+}
+
+// Rows returns the letters of the layout by row, followed by a row of the guessed letters that aren't in the layout, like
+// ones with accents, if there are any
+func (this Keyboard) Rows(layout []string) [][]rune {
+	rows := make([][]rune, 0, len(layout)+1)
+	var extra []rune
+	for letter := range this {
+		if !slices.ContainsFunc(layout, func(row string) bool { return strings.ContainsRune(row, letter) }) {
+			extra = append(extra, letter)
+		}
+	}
+	for _, row := range layout {
+		rows = append(rows, []rune(row))
+	}
+	if len(extra) > 0 {
+		slices.Sort(extra)
+		rows = append(rows, extra)
+	}
+	return rows
+}
+
+// String returns the QWERTY keyboard as plain text, with green letters like [E], yellow letters like (E), gray letters
+// as · and the letters that weren't guessed as they are
+func (this Keyboard) String() string {
+	var builder strings.Builder
+	for i, row := range this.Rows(QWERTY) {
+		builder.WriteString(strings.Repeat(" ", i))
+		for _, letter := range row {
+			switch this[letter] {
+			case Green:
+				builder.WriteString("[" + upper(letter) + "]")
+			case Yellow:
+				builder.WriteString("(" + upper(letter) + ")")
+			case Gray:
+				builder.WriteString(" · ")
+			default:
+				builder.WriteString(" " + string(letter) + " ")
+			}
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// FprintKeyboard will write the QWERTY keyboard to w with the best known color of every guessed letter, with
+// DefaultRenderer
+//
+// Deprecated: use a Renderer, like DefaultRenderer().RenderKeyboard(w, keyboard)
+func FprintKeyboard(w io.Writer, keyboard Keyboard) {
+	DefaultRenderer().RenderKeyboard(w, keyboard)
+}
@@ -0,0 +1,55 @@
+package wordle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestKeyboardFixture(t *testing.T) {
+	gunit.Run(new(KeyboardFixture), t)
+}
+
+type KeyboardFixture struct {
+	*gunit.Fixture
+}
+
+func (this *KeyboardFixture) TestBestColor() {
+	keyboard := NewKeyboard(turnsFor("sheen", "elate", "siren", "sheen"))
+	this.So(keyboard, should.Resemble, Keyboard{
+		'e': Green, 'l': Gray, 'a': Gray, 't': Gray, 's': Green, 'i': Gray, 'r': Gray, 'n': Green, 'h': Green,
+	})
+	this.So(NewKeyboard(nil), should.BeEmpty)
+}
+
+func (this *KeyboardFixture) TestYellowAndGrayLetter() {
+	keyboard := NewKeyboard(turnsFor("stare", "steer"))
+	this.So(keyboard['e'], should.Equal, Yellow)
+	this.So(keyboard['r'], should.Equal, Yellow)
+}
+
+func (this *KeyboardFixture) TestRows() {
+	keyboard := NewKeyboard(turnsFor("ñandú", "ñandu"))
+	rows := keyboard.Rows(QWERTY)
+	this.So(rows, should.HaveLength, 4)
+	this.So(string(rows[3]), should.Equal, "ñ")
+	this.So(NewKeyboard(nil).Rows(QWERTY), should.HaveLength, 3)
+}
+
+func (this *KeyboardFixture) TestString() {
+	lines := strings.Split(NewKeyboard(turnsFor("snake", "slain")).String(), "\n")
+	this.So(lines[0], should.Equal, " q  w  e  r  t  y  u  ·  o  p ")
+	this.So(lines[1], should.Equal, " [A][S] d  f  g  h  j  k  · ")
+	this.So(lines[2], should.Equal, "   z  x  c  v  b (N) m ")
+}
+
+func (this *KeyboardFixture) TestFprintKeyboard() {
+	keyboard := NewKeyboard(turnsFor("snake", "slain"))
+	var output, rendered bytes.Buffer
+	FprintKeyboard(&output, keyboard)
+	DefaultRenderer().RenderKeyboard(&rendered, keyboard)
+	this.So(output.String(), should.Equal, rendered.String())
+}
@@ -0,0 +1,34 @@
+package wordle
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// A letter is a rune of a word in Unicode normalization form C, where a letter with an accent, like ñ or ü, is a
+// single rune. Words from anywhere but a Dictionary should go through NormalizeWord before they are compared.
+
+// NormalizeWord lowercases the word and puts it in normalization form C, so the same letters are always the same runes
+func NormalizeWord(word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if isASCII(word) {
+		return word
+	}
+	return norm.NFC.String(word)
+}
+
+// NumLetters returns the number of letters in the word
+func NumLetters(word string) int {
+	return utf8.RuneCountInString(word)
+}
+
+func isASCII(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
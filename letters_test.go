@@ -0,0 +1,70 @@
+package wordle
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected string
+	}{
+		{name: "ascii", word: " Crane ", expected: "crane"},
+		{name: "precomposed", word: "ÑANDÚ", expected: "ñandú"},
+		{name: "decomposed", word: "Ñandú", expected: "ñandú"},
+		{name: "cyrillic", word: "СЛОВО", expected: "слово"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if normalized := NormalizeWord(tt.word); normalized != tt.expected {
+				t.Errorf("NormalizeWord(%q) = %q, want %q", tt.word, normalized, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckGuessLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		guess    string
+		expected Pattern
+	}{
+		{name: "spanish", target: "árbol", guess: "abrir", expected: Pattern{Gray, Yellow, Yellow, Gray, Gray}},
+		{name: "accents are different letters", target: "ñandú", guess: "ñandu", expected: Pattern{Green, Green, Green, Green, Gray}},
+		{name: "german", target: "größe", guess: "grüße", expected: Pattern{Green, Green, Gray, Green, Green}},
+		{name: "greek", target: "λόγος", guess: "λογος", expected: Pattern{Green, Gray, Green, Green, Green}},
+		{name: "cyrillic duplicate letters", target: "слово", guess: "олово", expected: Pattern{Gray, Green, Green, Green, Green}},
+		{name: "ascii guess against unicode target", target: "ñandú", guess: "nadir", expected: Pattern{Yellow, Green, Yellow, Gray, Gray}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pattern := CheckGuess(tt.target, tt.guess); pattern != tt.expected {
+				t.Errorf("CheckGuess(%q, %q) = %s, want %s", tt.target, tt.guess, pattern, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHardModeLetters(t *testing.T) {
+	turns := []Turn{{Guess: "ñandú", Pattern: CheckGuess("ñoñez", "ñandú")}}
+	err := CheckHardMode(turns, "nieve")
+	if err == nil || err.(*HardModeError).Hint != "1st letter must be Ñ" {
+		t.Errorf("CheckHardMode returned %v, want a hint about Ñ", err)
+	}
+	if err := CheckHardMode(turns, "ñoñez"); err != nil {
+		t.Errorf("CheckHardMode returned %v for a guess that uses every hint", err)
+	}
+}
+
+func TestFprintPatternLetters(t *testing.T) {
+	turn := Turn{"ñandú", Pattern{Green, Gray, Yellow, Gray, Gray}}
+	var output, rendered bytes.Buffer
+	FprintPattern(&output, turn.Pattern, turn.Guess)
+	DefaultRenderer().RenderTurn(&rendered, turn)
+	if output.String() != rendered.String() {
+		t.Errorf("FprintPattern wrote %q, want %q", output.String(), rendered.String())
+	}
+}
@@ -0,0 +1,205 @@
+package wordle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// The number of boards in the popular multi-board variants
+const (
+	Dordle   = 2
+	Quordle  = 4
+	Octordle = 8
+)
+
+// MultiSolver plays games where every guess is scored against several targets at once, one per board
+type MultiSolver interface {
+	// Debug returns true if the solver is in debug mode
+	Debug() bool
+	// Guess returns the next guess from the solver given the turn history of every board. A board's history stops
+	// growing once it is solved, so the last turn of a solved board is its correct guess.
+	Guess(boardHistories [][]Turn) string
+	// Reset will reset the original state of the solver between games
+	Reset()
+}
+
+// MultiGameResult is how a solver did on a single multi-board game
+type MultiGameResult struct {
+	Targets    []string // the target of every board
+	NumGuesses int      // the number of guesses made, including the one that solved the last board
+	SolvedOn   []int    // SolvedOn[i] is the guess that solved board i, starting at 1, or 0 if it wasn't solved
+	Boards     [][]Turn // the turn history of every board
+	Err        error    // why the game was not won, nil if it was
+}
+
+// Won returns true if the solver solved every board
+func (this MultiGameResult) Won() bool {
+	return this.Err == nil
+}
+
+// MultiEvaluationReport summarizes how a solver did on a set of multi-board games. The guess statistics only count the
+// games that were won.
+type MultiEvaluationReport struct {
+	Games     []MultiGameResult // every game in the order it was played
+	Histogram []int             // Histogram[n] is the number of games won in n guesses
+	Failures  []MultiGameResult // the games that were not won
+	WinRate   float64           // the fraction of games that were won
+	Mean      float64           // the mean number of guesses per game won
+	Max       int               // the most guesses needed to win a game
+}
+
+func newMultiEvaluationReport(games []MultiGameResult) *MultiEvaluationReport {
+	report := MultiEvaluationReport{Games: games}
+	totalGuesses := 0
+	for _, game := range games {
+		if !game.Won() {
+			report.Failures = append(report.Failures, game)
+			continue
+		}
+		totalGuesses += game.NumGuesses
+		report.Max = max(report.Max, game.NumGuesses)
+		for len(report.Histogram) <= game.NumGuesses {
+			report.Histogram = append(report.Histogram, 0)
+		}
+		report.Histogram[game.NumGuesses]++
+	}
+	if numWon := len(games) - len(report.Failures); numWon > 0 {
+		report.WinRate = float64(numWon) / float64(len(games))
+		report.Mean = float64(totalGuesses) / float64(numWon)
+	}
+	return &report
+}
+
+// MaxNumMultiGuesses returns the number of guesses allowed in a game with numBoards boards, which is one more guess for
+// every board after the first than the rules allow for a single board
+func (this Rules) MaxNumMultiGuesses(numBoards int) int {
+	return this.MaxNumGuesses + numBoards - 1
+}
+
+// validateMulti returns an error wrapping ErrInvalidRules if the rules can't be played on several boards at once. Hard
+// mode can't, since the hints of different boards can contradict each other.
+func (this Rules) validateMulti() error {
+	if err := this.Validate(); err != nil {
+		return err
+	}
+	if this.HardMode {
+		return fmt.Errorf("%w: hard mode can't be played on more than one board", ErrInvalidRules)
+	}
+	return nil
+}
+
+// EvaluateMultiSolver will split the targets into games of numBoards boards, play each of them with the solver and
+// report how it did. Targets left over after the last full game are not played.
+func (this *Evaluator) EvaluateMultiSolver(solver MultiSolver, numBoards int) (*MultiEvaluationReport, error) {
+	return this.EvaluateMultiSolverContext(context.Background(), solver, numBoards)
+}
+
+// EvaluateMultiSolverContext is EvaluateMultiSolver but stops when ctx is done, returning ctx.Err() with a report of
+// the games that were finished
+func (this *Evaluator) EvaluateMultiSolverContext(ctx context.Context, solver MultiSolver, numBoards int) (*MultiEvaluationReport, error) {
+	if err := this.rules.validateMulti(); err != nil {
+		return newMultiEvaluationReport(nil), err
+	}
+	if numBoards < 1 {
+		return newMultiEvaluationReport(nil), fmt.Errorf("%w: a game needs at least 1 board, not %d", ErrInvalidRules, numBoards)
+	}
+	numGames := len(this.validTargetSlice) / numBoards
+	if solver.Debug() {
+		numGames = min(numGames, 1)
+	}
+	var games []MultiGameResult
+	for i := range numGames {
+		solver.Reset()
+		game := this.PlayMultiGameContext(ctx, this.validTargetSlice[i*numBoards:(i+1)*numBoards], solver)
+		if err := ctx.Err(); err != nil && errors.Is(game.Err, err) {
+			return newMultiEvaluationReport(games), err
+		}
+		games = append(games, game)
+		this.progress(i+1, numGames)
+		if (this.failFast && game.Err != nil) || errors.Is(game.Err, ErrGuessTimeout) {
+			return newMultiEvaluationReport(games), game.Err
+		}
+	}
+	return newMultiEvaluationReport(games), nil
+}
+
+// PlayMultiGame will simulate a single game with one board per target
+func (this *Evaluator) PlayMultiGame(targets []string, solver MultiSolver) MultiGameResult {
+	return this.PlayMultiGameContext(context.Background(), targets, solver)
+}
+
+// PlayMultiGameContext is PlayMultiGame but gives up when ctx is done, with ctx.Err() as the result's error
+func (this *Evaluator) PlayMultiGameContext(ctx context.Context, targets []string, solver MultiSolver) MultiGameResult {
+	debug := solver.Debug()
+	game := MultiGameResult{
+		Targets:  slices.Clone(targets),
+		SolvedOn: make([]int, len(targets)),
+		Boards:   make([][]Turn, len(targets)),
+	}
+	if err := this.rules.validateMulti(); err != nil {
+		game.Err = err
+		return game
+	}
+	for _, target := range targets {
+		if NumLetters(target) != this.rules.WordLength {
+			game.Err = fmt.Errorf("%w: \"%s\" does not have %d letters", ErrInvalidTarget, target, this.rules.WordLength)
+			return game
+		}
+	}
+
+	numSolved := 0
+	for i := 1; i <= this.rules.MaxNumMultiGuesses(len(targets)); i++ {
+		if err := ctx.Err(); err != nil {
+			game.Err = err
+			return game
+		}
+		guess, err := this.askForGuess(ctx, func() string { return solver.Guess(cloneBoards(game.Boards)) })
+		if err == nil {
+			err = solverErr(solver)
+		}
+		if errors.Is(err, ErrGuessTimeout) {
+			game.Err = &GuessTimeoutError{Target: fmt.Sprint(targets), Turn: i, Timeout: this.guessTimeout}
+			return game
+		}
+		if err == nil {
+			err = this.validateGuess(guess)
+		}
+		if err != nil {
+			game.Err = err
+			return game
+		}
+
+		game.NumGuesses = i
+		for board, target := range targets {
+			if game.SolvedOn[board] != 0 {
+				continue
+			}
+			pattern := CheckGuess(target, guess)
+			game.Boards[board] = append(game.Boards[board], Turn{guess, pattern})
+			if pattern.IsCorrect() {
+				game.SolvedOn[board] = i
+				numSolved++
+			}
+			if debug {
+				fmt.Fprintf(this.output, "board %d: ", board+1)
+				this.renderer.RenderTurn(this.output, Turn{guess, pattern})
+			}
+		}
+		if numSolved == len(targets) {
+			return game
+		}
+	}
+	game.Err = fmt.Errorf("%w: %v", ErrLostGame, targets)
+	return game
+}
+
+// cloneBoards returns a copy of the turn histories of the boards that a solver can change without changing them
+func cloneBoards(boards [][]Turn) [][]Turn {
+	clone := make([][]Turn, len(boards))
+	for i, turns := range boards {
+		clone[i] = slices.Clone(turns)
+	}
+	return clone
+}
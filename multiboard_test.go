@@ -0,0 +1,127 @@
+package wordle
+
+import (
+	"io"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestMultiBoardFixture(t *testing.T) {
+	gunit.Run(new(MultiBoardFixture), t)
+}
+
+type MultiBoardFixture struct {
+	*gunit.Fixture
+	Evaluator *Evaluator
+}
+
+func (this *MultiBoardFixture) Setup() {
+	targets := []string{"angry", "crane", "salet", "crank", "drank"}
+	this.Evaluator = NewEvaluator(WithTargets(targets), WithoutShuffle(), WithOutput(io.Discard))
+}
+
+func (this *MultiBoardFixture) TestPlayMultiGame() {
+	game := this.Evaluator.PlayMultiGame([]string{"angry", "crane"}, NewDummyMultiSolverFixedGuesses("crane", "salet", "angry"))
+	this.So(game.Err, should.BeNil)
+	this.So(game.NumGuesses, should.Equal, 3)
+	this.So(game.SolvedOn, should.Resemble, []int{3, 1})
+	this.So(game.Boards[0], should.HaveLength, 3)
+	this.So(game.Boards[1], should.HaveLength, 1)
+	this.So(game.Boards[1][0].Pattern.IsCorrect(), should.BeTrue)
+}
+
+func (this *MultiBoardFixture) TestPlayMultiGameGuessLimit() {
+	game := this.Evaluator.PlayMultiGame([]string{"angry", "crane", "crank"}, NewDummyMultiSolverFixedGuesses("crane"))
+	this.So(game.Err, should.Wrap, ErrLostGame)
+	this.So(game.NumGuesses, should.Equal, MaxNumGuesses+2)
+	this.So(game.SolvedOn, should.Resemble, []int{0, 1, 0})
+}
+
+func (this *MultiBoardFixture) TestPlayMultiGameInvalidGuess() {
+	game := this.Evaluator.PlayMultiGame([]string{"angry", "crane"}, NewDummyMultiSolverFixedGuesses("sssss"))
+	this.So(game.Err, should.Wrap, ErrInvalidGuess)
+}
+
+func (this *MultiBoardFixture) TestPlayMultiGameInvalidTarget() {
+	game := this.Evaluator.PlayMultiGame([]string{"crane", "abcdefghijklmn"}, NewDummyMultiSolverFixedGuesses("crane"))
+	this.So(game.Err, should.Wrap, ErrInvalidTarget)
+	this.So(game.NumGuesses, should.Equal, 0)
+
+	game = this.Evaluator.PlayMultiGame([]string{"crane", "cranes"}, NewDummyMultiSolverFixedGuesses("crane"))
+	this.So(game.Err, should.Wrap, ErrInvalidTarget)
+}
+
+func (this *MultiBoardFixture) TestEvaluateMultiSolver() {
+	report, err := this.Evaluator.EvaluateMultiSolver(NewDummyMultiSolverFixedGuesses("angry", "crane", "salet", "crank"), Dordle)
+	this.So(err, should.BeNil)
+	this.So(report.Games, should.HaveLength, 2)
+	this.So(report.Games[0].SolvedOn, should.Resemble, []int{1, 2})
+	this.So(report.Games[1].SolvedOn, should.Resemble, []int{3, 4})
+	this.So(report.WinRate, should.Equal, 1)
+	this.So(report.Mean, should.Equal, 3)
+	this.So(report.Max, should.Equal, 4)
+	this.So(report.Histogram, should.Resemble, []int{0, 0, 1, 0, 1})
+}
+
+func (this *MultiBoardFixture) TestEvaluateMultiSolverNoBoards() {
+	_, err := this.Evaluator.EvaluateMultiSolver(NewDummyMultiSolverFixedGuesses("angry"), 0)
+	this.So(err, should.Wrap, ErrInvalidRules)
+}
+
+func (this *MultiBoardFixture) TestHardModeIsRejected() {
+	evaluator := NewEvaluator(WithTargets([]string{"angry", "crane"}), WithOutput(io.Discard), WithHardMode())
+	_, err := evaluator.EvaluateMultiSolver(NewDummyMultiSolverFixedGuesses("angry", "crane"), Dordle)
+	this.So(err, should.Wrap, ErrInvalidRules)
+	game := evaluator.PlayMultiGame([]string{"angry", "crane"}, NewDummyMultiSolverFixedGuesses("angry", "crane"))
+	this.So(game.Err, should.Wrap, ErrInvalidRules)
+}
+
+func (this *MultiBoardFixture) TestSolverCantChangeTheBoards() {
+	solver := meddlingMultiSolver{NewDummyMultiSolverFixedGuesses("crane", "salet", "angry")}
+	game := this.Evaluator.PlayMultiGame([]string{"angry", "crane"}, solver)
+	this.So(game.Err, should.BeNil)
+	this.So(game.Boards[0][0].Guess, should.Equal, "crane")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// meddlingMultiSolver changes the histories it is given
+type meddlingMultiSolver struct {
+	MultiSolver
+}
+
+func (this meddlingMultiSolver) Guess(boardHistories [][]Turn) string {
+	guess := this.MultiSolver.Guess(boardHistories)
+	for _, turnHistory := range boardHistories {
+		for i := range turnHistory {
+			turnHistory[i].Guess = "xxxxx"
+		}
+	}
+	return guess
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type DummyMultiSolverFixedGuesses struct {
+	guesses []string
+}
+
+func NewDummyMultiSolverFixedGuesses(guesses ...string) MultiSolver {
+	return &DummyMultiSolverFixedGuesses{guesses: guesses}
+}
+
+func (this DummyMultiSolverFixedGuesses) Debug() bool {
+	return false
+}
+
+func (this DummyMultiSolverFixedGuesses) Guess(boardHistories [][]Turn) string {
+	numGuesses := 0
+	for _, turnHistory := range boardHistories {
+		numGuesses = max(numGuesses, len(turnHistory))
+	}
+	return this.guesses[min(numGuesses, len(this.guesses)-1)]
+}
+
+func (this DummyMultiSolverFixedGuesses) Reset() {}
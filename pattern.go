@@ -0,0 +1,139 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidPattern    = errors.New("invalid pattern")
+	ErrImpossiblePattern = errors.New("no guess can get this pattern")
+)
+
+// patternSymbols maps every symbol ParsePattern accepts to its color
+var patternSymbols = map[rune]LetterColor{
+	'G': Green, 'g': Green, '2': Green, '🟩': Green, '🟧': Green,
+	'Y': Yellow, 'y': Yellow, '1': Yellow, '🟨': Yellow, '🟦': Yellow,
+	'.': Gray, '-': Gray, '_': Gray, '0': Gray, 'B': Gray, 'b': Gray, 'X': Gray, 'x': Gray, 'W': Gray, 'w': Gray,
+	'⬛': Gray, '⬜': Gray,
+}
+
+// NumPatterns returns the number of patterns, possible or not, for words with wordLength letters
+func NumPatterns(wordLength int) int {
+	n := 1
+	for range wordLength {
+		n *= 3
+	}
+	return n
+}
+
+// Index returns the pattern as a number from 0 to NumPatterns(pattern.Len())-1, where the color of the first letter is
+// the least significant base 3 digit: 0 for gray, 1 for yellow and 2 for green
+func (this Pattern) Index() int {
+	index := 0
+	colors := this.Colors()
+	for i := len(colors) - 1; i >= 0; i-- {
+		index = index*3 + int(colors[i]-Gray)
+	}
+	return index
+}
+
+// PatternFromIndex returns the pattern for words with wordLength letters that has the index, which must be less than
+// NumPatterns(wordLength)
+func PatternFromIndex(index, wordLength int) (pattern Pattern) {
+	for i := range wordLength {
+		pattern[i] = Gray + LetterColor(index%3)
+		index /= 3
+	}
+	return pattern
+}
+
+// IsPossible returns false if no guess can get the pattern. That is the case for an empty pattern and for a pattern
+// that is all green except for one yellow, since that yellow letter would have to be in the one spot that isn't green.
+func (this Pattern) IsPossible() bool {
+	colors := this.Colors()
+	numGreen, numYellow := 0, 0
+	for _, color := range colors {
+		switch color {
+		case Green:
+			numGreen++
+		case Yellow:
+			numYellow++
+		}
+	}
+	return len(colors) > 0 && !(numYellow == 1 && numGreen == len(colors)-1)
+}
+
+// AllPatterns returns every possible pattern for words with wordLength letters, ordered by index
+func AllPatterns(wordLength int) []Pattern {
+	var patterns []Pattern
+	for index := range NumPatterns(wordLength) {
+		if pattern := PatternFromIndex(index, wordLength); pattern.IsPossible() {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// String returns the pattern with a G for each green, a Y for each yellow and a . for each gray, like "GY..G"
+func (this Pattern) String() string {
+	var builder strings.Builder
+	for _, color := range this.Colors() {
+		switch color {
+		case Green:
+			builder.WriteByte('G')
+		case Yellow:
+			builder.WriteByte('Y')
+		default:
+			builder.WriteByte('.')
+		}
+	}
+	return builder.String()
+}
+
+// ParsePattern reads a pattern written with one symbol per letter. Green can be written as G, 2, 🟩 or 🟧 (high
+// contrast), yellow as Y, 1, 🟨 or 🟦 (high contrast), and gray as ., -, _, 0, B, X, W, ⬛ or ⬜. Letters can be either
+// case, notations can be mixed and whitespace is ignored, so "GY..G", "gybbg", "21002" and "🟩🟨⬛⬛🟩" are the same
+// pattern. Patterns that no guess can get are rejected with ErrImpossiblePattern.
+func ParsePattern(text string) (Pattern, error) {
+	var pattern Pattern
+	length := 0
+	for _, symbol := range text {
+		if unicode.IsSpace(symbol) || symbol == '\uFE0F' { // emoji can come with a variation selector
+			continue
+		}
+		color, ok := patternSymbols[symbol]
+		if !ok {
+			return Pattern{}, fmt.Errorf("%w: \"%s\": unknown symbol %q", ErrInvalidPattern, text, symbol)
+		}
+		if length == MaxWordLength {
+			return Pattern{}, fmt.Errorf("%w: \"%s\": more than %d colors", ErrInvalidPattern, text, MaxWordLength)
+		}
+		pattern[length] = color
+		length++
+	}
+	if length == 0 {
+		return Pattern{}, fmt.Errorf("%w: \"%s\": no colors", ErrInvalidPattern, text)
+	}
+	if !pattern.IsPossible() {
+		return Pattern{}, fmt.Errorf("%w: \"%s\"", ErrImpossiblePattern, text)
+	}
+	return pattern, nil
+}
+
+// MarshalText returns the pattern as String does
+func (this Pattern) MarshalText() ([]byte, error) {
+	return []byte(this.String()), nil
+}
+
+// UnmarshalText reads the pattern with ParsePattern
+func (this *Pattern) UnmarshalText(text []byte) error {
+	pattern, err := ParsePattern(string(text))
+	if err != nil {
+		return err
+	}
+	*this = pattern
+	return nil
+}
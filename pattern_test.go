@@ -0,0 +1,89 @@
+package wordle
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestPatternFixture(t *testing.T) {
+	gunit.Run(new(PatternFixture), t)
+}
+
+type PatternFixture struct {
+	*gunit.Fixture
+}
+
+func (this *PatternFixture) TestIndex() {
+	this.So(Pattern{Gray, Gray, Gray, Gray, Gray}.Index(), should.Equal, 0)
+	this.So(Pattern{Yellow, Gray, Gray, Gray, Gray}.Index(), should.Equal, 1)
+	this.So(Pattern{Gray, Green, Gray, Gray, Gray}.Index(), should.Equal, 6)
+	this.So(CorrectPattern.Index(), should.Equal, 242)
+	this.So(NumPatterns(WordLength), should.Equal, 243)
+}
+
+func (this *PatternFixture) TestIndexRoundTrip() {
+	for wordLength := 1; wordLength <= 6; wordLength++ {
+		for index := range NumPatterns(wordLength) {
+			pattern := PatternFromIndex(index, wordLength)
+			this.So(pattern.Len(), should.Equal, wordLength)
+			this.So(pattern.Index(), should.Equal, index)
+		}
+	}
+}
+
+func (this *PatternFixture) TestIsPossible() {
+	this.So(CorrectPattern.IsPossible(), should.BeTrue)
+	this.So(Pattern{Gray, Gray, Gray, Gray, Gray}.IsPossible(), should.BeTrue)
+	this.So(Pattern{Green, Green, Yellow, Green, Green}.IsPossible(), should.BeFalse)
+	this.So(Pattern{Green, Green, Yellow, Yellow, Green}.IsPossible(), should.BeTrue)
+	this.So(Pattern{Yellow}.IsPossible(), should.BeFalse)
+	this.So(Pattern{}.IsPossible(), should.BeFalse)
+}
+
+func (this *PatternFixture) TestAllPatterns() {
+	patterns := AllPatterns(WordLength)
+	this.So(patterns, should.HaveLength, 243-5)
+	this.So(patterns[0], should.Equal, Pattern{Gray, Gray, Gray, Gray, Gray})
+	this.So(patterns[len(patterns)-1], should.Equal, CorrectPattern)
+	for _, pattern := range patterns {
+		this.So(pattern.IsPossible(), should.BeTrue)
+	}
+}
+
+func (this *PatternFixture) TestString() {
+	this.So(Pattern{Green, Yellow, Gray, Gray, Green}.String(), should.Equal, "GY..G")
+	this.So(Pattern{}.String(), should.Equal, "")
+}
+
+func (this *PatternFixture) TestParsePatternNotations() {
+	expected := Pattern{Green, Yellow, Gray, Gray, Green}
+	for _, text := range []string{"GY..G", "gybbg", "GYXXG", "g y - _ g", "21002", "🟩🟨⬛⬜🟩", "🟧🟦⬛⬛🟧", "GY⬛0g"} {
+		pattern, err := ParsePattern(text)
+		this.So(err, should.BeNil)
+		this.So(pattern, should.Equal, expected)
+	}
+}
+
+func (this *PatternFixture) TestParsePatternInvalid() {
+	for _, text := range []string{"", "   ", "GY..Z", "GGGGGGGGGGG"} {
+		_, err := ParsePattern(text)
+		this.So(err, should.Wrap, ErrInvalidPattern)
+	}
+	_, err := ParsePattern("GGYGG")
+	this.So(err, should.Wrap, ErrImpossiblePattern)
+}
+
+func (this *PatternFixture) TestText() {
+	turn := Turn{Guess: "slain", Pattern: Pattern{Green, Gray, Green, Gray, Yellow}}
+	encoded, err := json.Marshal(turn)
+	this.So(err, should.BeNil)
+	this.So(string(encoded), should.Equal, `{"guess":"slain","pattern":"G.G.Y"}`)
+
+	var decoded Turn
+	this.So(json.Unmarshal(encoded, &decoded), should.BeNil)
+	this.So(decoded, should.Equal, turn)
+	this.So(json.Unmarshal([]byte(`{"pattern":"GGGGY"}`), &decoded), should.Wrap, ErrImpossiblePattern)
+}
@@ -0,0 +1,289 @@
+package wordle
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// MaxPatternTableWordLength is the longest word length a PatternTable can hold, since every pattern index has to fit
+// in a byte
+const MaxPatternTableWordLength = 5
+
+const patternTableVersion = 1
+
+var (
+	ErrInvalidPatternTable = errors.New("invalid pattern table")
+	patternTableMagic      = [4]byte{'W', 'P', 'T', 'B'}
+)
+
+// PatternTable holds the pattern index (see Pattern.Index) of every guess against every target, so that solvers can
+// look patterns up instead of computing them over and over
+type PatternTable struct {
+	guesses     []string
+	targets     []string
+	guessIndex  map[string]int
+	targetIndex map[string]int
+	wordLength  int // the number of letters in every word, 0 if there are no words
+	key         [sha256.Size]byte
+	patterns    []byte // the pattern for guess g and target t is at g*len(targets)+t
+}
+
+// patternTableHeader is the start of a saved PatternTable. It is followed by the patterns and a CRC-32 checksum of
+// everything before it.
+type patternTableHeader struct {
+	Magic      [4]byte
+	Version    uint16
+	WordLength uint16
+	NumGuesses uint32
+	NumTargets uint32
+	Key        [sha256.Size]byte
+}
+
+// NewPatternTable computes the pattern of every guess against every target, in parallel. All the words must have the
+// same length, which can be at most MaxPatternTableWordLength.
+func NewPatternTable(guesses, targets []string) (*PatternTable, error) {
+	table, err := newEmptyPatternTable(guesses, targets)
+	if err != nil {
+		return nil, err
+	}
+	table.patterns = make([]byte, len(guesses)*len(targets))
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for range runtime.NumCPU() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for g := range rows {
+				row := table.Row(g)
+				for t, target := range targets {
+					row[t] = byte(CheckGuess(target, guesses[g]).Index())
+				}
+			}
+		}()
+	}
+	for g := range guesses {
+		rows <- g
+	}
+	close(rows)
+	wg.Wait()
+	return table, nil
+}
+
+func newEmptyPatternTable(guesses, targets []string) (*PatternTable, error) {
+	wordLength, err := patternTableWordLength(guesses, targets)
+	if err != nil {
+		return nil, err
+	}
+	table := PatternTable{
+		guesses:     guesses,
+		targets:     targets,
+		guessIndex:  make(map[string]int, len(guesses)),
+		targetIndex: make(map[string]int, len(targets)),
+		wordLength:  wordLength,
+		key:         patternTableKey(wordLength, guesses, targets),
+	}
+	for i, guess := range guesses {
+		table.guessIndex[guess] = i
+	}
+	for i, target := range targets {
+		table.targetIndex[target] = i
+	}
+	return &table, nil
+}
+
+func patternTableWordLength(guesses, targets []string) (int, error) {
+	wordLength := 0
+	for _, words := range [][]string{guesses, targets} {
+		for _, word := range words {
+			if wordLength == 0 {
+				wordLength = NumLetters(word)
+			}
+			if NumLetters(word) != wordLength {
+				return 0, fmt.Errorf("%w: \"%s\" does not have %d letters like the other words", ErrInvalidPatternTable, word, wordLength)
+			}
+		}
+	}
+	if wordLength > MaxPatternTableWordLength {
+		return 0, fmt.Errorf("%w: words can have at most %d letters, not %d", ErrInvalidPatternTable, MaxPatternTableWordLength, wordLength)
+	}
+	return wordLength, nil
+}
+
+// patternTableKey identifies the word lists a table was computed for
+func patternTableKey(wordLength int, guesses, targets []string) [sha256.Size]byte {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d\n", wordLength)
+	for _, words := range [][]string{guesses, targets} {
+		fmt.Fprintf(hash, "%d\n", len(words))
+		for _, word := range words {
+			fmt.Fprintf(hash, "%s\n", word)
+		}
+	}
+	var key [sha256.Size]byte
+	copy(key[:], hash.Sum(nil))
+	return key
+}
+
+// Guesses returns the guesses in the order of their indexes
+func (this *PatternTable) Guesses() []string {
+	return this.guesses
+}
+
+// Targets returns the targets in the order of their indexes
+func (this *PatternTable) Targets() []string {
+	return this.targets
+}
+
+// GuessIndex returns the index of the guess, or false if the table doesn't have it
+func (this *PatternTable) GuessIndex(guess string) (int, bool) {
+	index, ok := this.guessIndex[guess]
+	return index, ok
+}
+
+// TargetIndex returns the index of the target, or false if the table doesn't have it
+func (this *PatternTable) TargetIndex(target string) (int, bool) {
+	index, ok := this.targetIndex[target]
+	return index, ok
+}
+
+// PatternIndex returns the pattern index the guess at guessIndex gets against the target at targetIndex
+func (this *PatternTable) PatternIndex(guessIndex, targetIndex int) byte {
+	return this.patterns[guessIndex*len(this.targets)+targetIndex]
+}
+
+// Row returns the pattern index the guess at guessIndex gets against every target, by target index. The row must not
+// be modified.
+func (this *PatternTable) Row(guessIndex int) []byte {
+	return this.patterns[guessIndex*len(this.targets) : (guessIndex+1)*len(this.targets)]
+}
+
+// WriteTo writes the table in a versioned binary format with a checksum, which ReadPatternTable can read back
+func (this *PatternTable) WriteTo(w io.Writer) (int64, error) {
+	header := patternTableHeader{
+		Magic:      patternTableMagic,
+		Version:    patternTableVersion,
+		WordLength: uint16(this.wordLength),
+		NumGuesses: uint32(len(this.guesses)),
+		NumTargets: uint32(len(this.targets)),
+		Key:        this.key,
+	}
+	checksum := crc32.NewIEEE()
+	counter := &countingWriter{w: io.MultiWriter(w, checksum)}
+	if err := binary.Write(counter, binary.LittleEndian, header); err != nil {
+		return counter.n, err
+	}
+	if _, err := counter.Write(this.patterns); err != nil {
+		return counter.n, err
+	}
+	err := binary.Write(w, binary.LittleEndian, checksum.Sum32())
+	return counter.n + 4, err
+}
+
+// ReadPatternTable reads a table written by WriteTo. It returns an error wrapping ErrInvalidPatternTable if the data is
+// corrupt, was written by another version, or was computed for different word lists.
+func ReadPatternTable(r io.Reader, guesses, targets []string) (*PatternTable, error) {
+	table, err := newEmptyPatternTable(guesses, targets)
+	if err != nil {
+		return nil, err
+	}
+	checksum := crc32.NewIEEE()
+	reader := io.TeeReader(bufio.NewReader(r), checksum)
+	var header patternTableHeader
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("%w: reading header: %w", ErrInvalidPatternTable, err)
+	}
+	if header.Magic != patternTableMagic {
+		return nil, fmt.Errorf("%w: not a pattern table", ErrInvalidPatternTable)
+	}
+	if header.Version != patternTableVersion {
+		return nil, fmt.Errorf("%w: version %d instead of %d", ErrInvalidPatternTable, header.Version, patternTableVersion)
+	}
+	if int(header.WordLength) != table.wordLength {
+		return nil, fmt.Errorf("%w: computed for words of %d letters instead of %d", ErrInvalidPatternTable,
+			header.WordLength, table.wordLength)
+	}
+	if header.Key != table.key || int(header.NumGuesses) != len(guesses) || int(header.NumTargets) != len(targets) {
+		return nil, fmt.Errorf("%w: computed for different word lists", ErrInvalidPatternTable)
+	}
+	table.patterns = make([]byte, len(guesses)*len(targets))
+	if _, err := io.ReadFull(reader, table.patterns); err != nil {
+		return nil, fmt.Errorf("%w: reading patterns: %w", ErrInvalidPatternTable, err)
+	}
+	expected := checksum.Sum32()
+	var actual uint32
+	if err := binary.Read(reader, binary.LittleEndian, &actual); err != nil {
+		return nil, fmt.Errorf("%w: reading checksum: %w", ErrInvalidPatternTable, err)
+	}
+	if actual != expected {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidPatternTable)
+	}
+	return table, nil
+}
+
+// LoadPatternTable returns the table for the word lists saved in dir, or computes it and saves it there if it can't
+// be loaded. Tables for different word lists are saved in different files, so they can share a directory.
+func LoadPatternTable(dir string, guesses, targets []string) (*PatternTable, error) {
+	wordLength, err := patternTableWordLength(guesses, targets)
+	if err != nil {
+		return nil, err
+	}
+	key := patternTableKey(wordLength, guesses, targets)
+	path := filepath.Join(dir, fmt.Sprintf("patterns-v%d-%x.bin", patternTableVersion, key[:8]))
+	if file, err := os.Open(path); err == nil {
+		table, err := ReadPatternTable(file, guesses, targets)
+		_ = file.Close()
+		if err == nil {
+			return table, nil
+		}
+	}
+	table, err := NewPatternTable(guesses, targets)
+	if err != nil {
+		return nil, err
+	}
+	return table, table.save(path)
+}
+
+// save writes the table to a temporary file that is then renamed to path, so path never holds a partial table
+func (this *PatternTable) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	writer := bufio.NewWriter(file)
+	if _, err := this.WriteTo(writer); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (this *countingWriter) Write(p []byte) (int, error) {
+	n, err := this.w.Write(p)
+	this.n += int64(n)
+	return n, err
+}
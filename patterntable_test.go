@@ -0,0 +1,142 @@
+package wordle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestPatternTableFixture(t *testing.T) {
+	gunit.Run(new(PatternTableFixture), t)
+}
+
+type PatternTableFixture struct {
+	*gunit.Fixture
+	guesses []string
+	targets []string
+	table   *PatternTable
+}
+
+func (this *PatternTableFixture) Setup() {
+	this.guesses = []string{"salet", "crane", "error", "sheen", "angry"}
+	this.targets = []string{"angry", "freer", "messy", "sheen"}
+	var err error
+	this.table, err = NewPatternTable(this.guesses, this.targets)
+	this.So(err, should.BeNil)
+}
+
+func (this *PatternTableFixture) TestPatternIndex() {
+	for g, guess := range this.guesses {
+		for t, target := range this.targets {
+			this.So(this.table.PatternIndex(g, t), should.Equal, CheckGuess(target, guess).Index())
+		}
+		this.So(this.table.Row(g), should.HaveLength, len(this.targets))
+	}
+}
+
+func (this *PatternTableFixture) TestIndexes() {
+	guess, ok := this.table.GuessIndex("error")
+	this.So(ok, should.BeTrue)
+	this.So(guess, should.Equal, 2)
+	target, ok := this.table.TargetIndex("sheen")
+	this.So(ok, should.BeTrue)
+	this.So(target, should.Equal, 3)
+	_, ok = this.table.GuessIndex("fuzzy")
+	this.So(ok, should.BeFalse)
+}
+
+func (this *PatternTableFixture) TestInvalidWords() {
+	_, err := NewPatternTable([]string{"salet", "tree"}, this.targets)
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+	_, err = NewPatternTable([]string{"banana"}, []string{"cabana"})
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+}
+
+func (this *PatternTableFixture) TestRoundTrip() {
+	var buffer bytes.Buffer
+	n, err := this.table.WriteTo(&buffer)
+	this.So(err, should.BeNil)
+	this.So(n, should.Equal, buffer.Len())
+
+	table, err := ReadPatternTable(&buffer, this.guesses, this.targets)
+	this.So(err, should.BeNil)
+	this.So(table.patterns, should.Resemble, this.table.patterns)
+}
+
+func (this *PatternTableFixture) TestHeaderWordLengthCountsLetters() {
+	words := []string{"ñandú", "árbol", "ñoñas"}
+	table, err := NewPatternTable(words, words)
+	this.So(err, should.BeNil)
+	var buffer bytes.Buffer
+	_, err = table.WriteTo(&buffer)
+	this.So(err, should.BeNil)
+	var header patternTableHeader
+	this.So(binary.Read(&buffer, binary.LittleEndian, &header), should.BeNil)
+	this.So(header.WordLength, should.Equal, 5)
+}
+
+func (this *PatternTableFixture) TestReadOtherWordLength() {
+	var buffer bytes.Buffer
+	_, _ = this.table.WriteTo(&buffer)
+	data := buffer.Bytes()
+	data[6]++ // the word length follows the magic number and the version
+	_, err := ReadPatternTable(bytes.NewReader(data), this.guesses, this.targets)
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+	this.So(err.Error(), should.ContainSubstring, "words of 6 letters instead of 5")
+}
+
+func (this *PatternTableFixture) TestReadDifferentWordLists() {
+	var buffer bytes.Buffer
+	_, _ = this.table.WriteTo(&buffer)
+	_, err := ReadPatternTable(&buffer, this.guesses, []string{"angry", "freer", "messy", "sheet"})
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+}
+
+func (this *PatternTableFixture) TestReadCorrupt() {
+	var buffer bytes.Buffer
+	_, _ = this.table.WriteTo(&buffer)
+	data := buffer.Bytes()
+	data[len(data)-6]++
+	_, err := ReadPatternTable(bytes.NewReader(data), this.guesses, this.targets)
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+
+	_, err = ReadPatternTable(bytes.NewReader(data[:20]), this.guesses, this.targets)
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+}
+
+func (this *PatternTableFixture) TestReadOtherVersion() {
+	var buffer bytes.Buffer
+	_, _ = this.table.WriteTo(&buffer)
+	data := buffer.Bytes()
+	data[4]++
+	_, err := ReadPatternTable(bytes.NewReader(data), this.guesses, this.targets)
+	this.So(err, should.Wrap, ErrInvalidPatternTable)
+}
+
+func (this *PatternTableFixture) TestLoadPatternTable() {
+	dir, err := os.MkdirTemp("", "patterntable")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	table, err := LoadPatternTable(dir, this.guesses, this.targets)
+	this.So(err, should.BeNil)
+	this.So(table.patterns, should.Resemble, this.table.patterns)
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	this.So(files, should.HaveLength, 1)
+
+	this.So(os.WriteFile(files[0], []byte("corrupt"), 0o644), should.BeNil)
+	table, err = LoadPatternTable(dir, this.guesses, this.targets)
+	this.So(err, should.BeNil)
+	this.So(table.patterns, should.Resemble, this.table.patterns)
+
+	file, err := os.Open(files[0])
+	this.So(err, should.BeNil)
+	defer file.Close()
+	loaded, err := ReadPatternTable(file, this.guesses, this.targets)
+	this.So(err, should.BeNil)
+	this.So(loaded.patterns, should.Resemble, this.table.patterns)
+}
@@ -0,0 +1,174 @@
+// Package daily decides the target of each day's puzzle, so everyone playing on the same day plays the same puzzle
+package daily
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tliddle1/wordle/data"
+)
+
+var (
+	ErrNoPuzzle        = errors.New("there is no puzzle for that day")
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
+
+// DefaultEpoch is the day of puzzle 0 of the original game
+var DefaultEpoch = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+const (
+	DefaultSeed = 1
+	// DateLayout is how dates are written in schedules and to the commands, like "2024-01-31"
+	DateLayout = "2006-01-02"
+)
+
+// Calendar numbers the days from its epoch and gives every day a target. The targets are played in an order that
+// only depends on the seed, going through all of them before repeating, unless a schedule says otherwise.
+type Calendar struct {
+	targets     []string
+	epoch       time.Time
+	permutation []int
+	schedule    Schedule
+}
+
+// Option configures a Calendar
+type Option func(*config)
+
+type config struct {
+	targets  []string
+	epoch    time.Time
+	seed     int64
+	schedule Schedule
+}
+
+// WithTargets replaces data.ValidTargets as the words the targets are picked from
+func WithTargets(targets []string) Option {
+	return func(config *config) {
+		config.targets = targets
+	}
+}
+
+// WithEpoch makes the date's day puzzle 0 instead of DefaultEpoch's
+func WithEpoch(date time.Time) Option {
+	return func(config *config) {
+		config.epoch = date
+	}
+}
+
+// WithSeed changes the order the targets are played in
+func WithSeed(seed int64) Option {
+	return func(config *config) {
+		config.seed = seed
+	}
+}
+
+// WithSchedule uses the schedule's target on the days it has one
+func WithSchedule(schedule Schedule) Option {
+	return func(config *config) {
+		config.schedule = schedule
+	}
+}
+
+func New(options ...Option) *Calendar {
+	config := config{
+		targets: data.ValidTargets,
+		epoch:   DefaultEpoch,
+		seed:    DefaultSeed,
+	}
+	for _, option := range options {
+		option(&config)
+	}
+	return &Calendar{
+		targets:     config.targets,
+		epoch:       civilDate(config.epoch),
+		permutation: rand.New(rand.NewSource(config.seed)).Perm(len(config.targets)),
+		schedule:    config.schedule,
+	}
+}
+
+// PuzzleNumber returns the number of the puzzle on the date's day where the date is, which is the number of days
+// since the epoch
+func (this *Calendar) PuzzleNumber(date time.Time) int {
+	return int(civilDate(date).Sub(this.epoch).Hours() / 24)
+}
+
+// Date returns the day of the puzzle with the number, at midnight UTC
+func (this *Calendar) Date(number int) time.Time {
+	return this.epoch.AddDate(0, 0, number)
+}
+
+// Target returns the target of the puzzle with the number
+func (this *Calendar) Target(number int) (string, error) {
+	if number < 0 {
+		return "", fmt.Errorf("%w: puzzle %d is before the first one", ErrNoPuzzle, number)
+	}
+	if target, ok := this.schedule[this.Date(number).Format(DateLayout)]; ok {
+		return target, nil
+	}
+	if len(this.targets) == 0 {
+		return "", fmt.Errorf("%w: there are no targets", ErrNoPuzzle)
+	}
+	return this.targets[this.permutation[number%len(this.targets)]], nil
+}
+
+// Today returns the number and target of the puzzle for the day it is at now, where now is
+func (this *Calendar) Today(now time.Time) (int, string, error) {
+	number := this.PuzzleNumber(now)
+	target, err := this.Target(number)
+	return number, target, err
+}
+
+// Schedule is the targets of some days by their date, like "2024-01-31"
+type Schedule map[string]string
+
+// ReadSchedule reads a schedule with a date and a target on every line, like "2024-01-31 crane". Blank lines and lines
+// starting with # are ignored.
+func ReadSchedule(r io.Reader) (Schedule, error) {
+	schedule := Schedule{}
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: line %d: want a date and a target, got %q", ErrInvalidSchedule, lineNumber, line)
+		}
+		date, err := time.Parse(DateLayout, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidSchedule, lineNumber, err)
+		}
+		key := date.Format(DateLayout)
+		if _, ok := schedule[key]; ok {
+			return nil, fmt.Errorf("%w: line %d: %s is scheduled twice", ErrInvalidSchedule, lineNumber, key)
+		}
+		schedule[key] = strings.ToLower(fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// LoadSchedule reads the schedule in the file at path (see ReadSchedule)
+func LoadSchedule(path string) (Schedule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadSchedule(file)
+}
+
+// civilDate returns midnight UTC of the date's day where the date is
+func civilDate(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
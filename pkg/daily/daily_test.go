@@ -0,0 +1,105 @@
+package daily
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle/data"
+)
+
+func TestDailyFixture(t *testing.T) {
+	gunit.Run(new(DailyFixture), t)
+}
+
+type DailyFixture struct {
+	*gunit.Fixture
+}
+
+func (this *DailyFixture) TestPuzzleNumber() {
+	calendar := New()
+	this.So(calendar.PuzzleNumber(DefaultEpoch), should.Equal, 0)
+	this.So(calendar.PuzzleNumber(time.Date(2022, time.January, 1, 23, 59, 0, 0, time.UTC)), should.Equal, 196)
+	this.So(calendar.Date(196), should.Equal, time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (this *DailyFixture) TestPuzzleNumberUsesTheLocalDay() {
+	calendar := New()
+	newYork := time.FixedZone("EST", -5*60*60)
+	utc := time.Date(2022, time.January, 2, 1, 0, 0, 0, time.UTC)
+	this.So(calendar.PuzzleNumber(utc), should.Equal, 197)
+	this.So(calendar.PuzzleNumber(utc.In(newYork)), should.Equal, 196)
+}
+
+func (this *DailyFixture) TestEveryTargetBeforeRepeating() {
+	calendar := New()
+	var targets []string
+	for number := range len(data.ValidTargets) {
+		target, err := calendar.Target(number)
+		this.So(err, should.BeNil)
+		targets = append(targets, target)
+	}
+	this.So(targets, should.NotResemble, data.ValidTargets)
+	slices.Sort(targets)
+	this.So(slices.Compact(targets), should.HaveLength, len(data.ValidTargets))
+
+	first, _ := calendar.Target(0)
+	again, _ := calendar.Target(len(data.ValidTargets))
+	this.So(again, should.Equal, first)
+}
+
+func (this *DailyFixture) TestDeterministic() {
+	a, _ := New().Target(1000)
+	b, _ := New().Target(1000)
+	this.So(a, should.Equal, b)
+
+	differentSeed := 0
+	for number := range 10 {
+		a, _ := New().Target(number)
+		b, _ := New(WithSeed(2)).Target(number)
+		if a != b {
+			differentSeed++
+		}
+	}
+	this.So(differentSeed, should.BeGreaterThan, 0)
+}
+
+func (this *DailyFixture) TestEpoch() {
+	calendar := New(WithEpoch(time.Date(2024, time.March, 1, 15, 0, 0, 0, time.UTC)), WithTargets([]string{"crane"}))
+	number, target, err := calendar.Today(time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC))
+	this.So(err, should.BeNil)
+	this.So(number, should.Equal, 2)
+	this.So(target, should.Equal, "crane")
+
+	_, _, err = calendar.Today(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))
+	this.So(errors.Is(err, ErrNoPuzzle), should.BeTrue)
+}
+
+func (this *DailyFixture) TestSchedule() {
+	schedule, err := ReadSchedule(strings.NewReader("# answers\n2021-06-20 CRANE\n\n2021-06-22 angry\n"))
+	this.So(err, should.BeNil)
+	calendar := New(WithSchedule(schedule), WithTargets([]string{"salet"}))
+	for number, expected := range []string{"salet", "crane", "salet", "angry"} {
+		target, err := calendar.Target(number)
+		this.So(err, should.BeNil)
+		this.So(target, should.Equal, expected)
+	}
+}
+
+func (this *DailyFixture) TestInvalidSchedule() {
+	for _, text := range []string{
+		"2021-06-20",
+		"2021-06-20 crane angry",
+		"June 20 crane",
+		"2021-06-20 crane\n2021-06-20 angry",
+	} {
+		_, err := ReadSchedule(strings.NewReader(text))
+		this.So(errors.Is(err, ErrInvalidSchedule), should.BeTrue)
+	}
+	_, err := ReadSchedule(strings.NewReader("2021-06-20 crane\nnope"))
+	this.So(err.Error(), should.ContainSubstring, "line 2")
+}
@@ -0,0 +1,345 @@
+// Package server serves games of wordle over a JSON API:
+//
+//	POST   /games               start a game, see NewGameRequest
+//	GET    /games/{id}          the game's state, see GameState
+//	POST   /games/{id}/guesses  submit a guess, see GuessRequest
+//	GET    /games/{id}/share    the share text of a game that is over, with an optional ?theme=
+//	DELETE /games/{id}          forget the game
+//
+// Errors are returned as {"error": "..."} with a 4xx status.
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	mathrand "math/rand"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tliddle1/wordle"
+	"github.com/tliddle1/wordle/data"
+	"github.com/tliddle1/wordle/pkg/daily"
+	"github.com/tliddle1/wordle/pkg/set"
+)
+
+const (
+	RandomMode = "random" // a random target
+	SeededMode = "seeded" // a target that only depends on the seed
+	DailyMode  = "daily"  // the same target for everyone on the same day
+)
+
+const maxRequestSize = 1 << 16
+
+// NewGameRequest is the body of a request to start a game. Every field is optional.
+type NewGameRequest struct {
+	Mode          string `json:"mode"`          // RandomMode, SeededMode or DailyMode, RandomMode if empty
+	Seed          int64  `json:"seed"`          // the seed for SeededMode
+	HardMode      bool   `json:"hardMode"`      // whether every hint has to be used in later guesses
+	MaxNumGuesses int    `json:"maxNumGuesses"` // the number of guesses allowed, wordle.MaxNumGuesses if 0
+}
+
+// GuessRequest is the body of a request to submit a guess
+type GuessRequest struct {
+	Guess string `json:"guess"`
+}
+
+// GameState is what a client can know about a game. The target is only given once the game is over.
+type GameState struct {
+	ID           string            `json:"id"`
+	Mode         string            `json:"mode"`
+	PuzzleNumber *int              `json:"puzzleNumber,omitempty"` // the daily puzzle's number, nil in other modes
+	Rules        wordle.Rules      `json:"rules"`
+	Status       wordle.GameStatus `json:"status"`
+	Turns        []wordle.Turn     `json:"turns"`
+	GuessesLeft  int               `json:"guessesLeft"`
+	Target       string            `json:"target,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server keeps the games being played in memory. Games that haven't been touched for the session TTL are forgotten.
+type Server struct {
+	mux        *http.ServeMux
+	targets    []string
+	words      set.Set[string]
+	sessionTTL time.Duration
+	calendar   *daily.Calendar
+	now        func() time.Time
+	random     func(n int) int
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+type session struct {
+	game         *wordle.Game
+	mode         string
+	puzzleNumber *int // nil unless the game is a daily puzzle
+	lastUsed     time.Time
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithWordLists replaces data.ValidTargets and data.ValidGuesses as the words games are played with. Targets are
+// always valid guesses.
+func WithWordLists(targets, guesses []string) Option {
+	return func(server *Server) {
+		server.targets = targets
+		server.words = set.Set[string]{}
+		for _, word := range slices.Concat(guesses, targets) {
+			server.words.Add(word)
+		}
+	}
+}
+
+// WithSessionTTL forgets games that haven't been touched for ttl instead of 24 hours
+func WithSessionTTL(ttl time.Duration) Option {
+	return func(server *Server) {
+		server.sessionTTL = ttl
+	}
+}
+
+// WithCalendar decides the daily targets with calendar instead of a daily.Calendar with the server's targets
+func WithCalendar(calendar *daily.Calendar) Option {
+	return func(server *Server) {
+		server.calendar = calendar
+	}
+}
+
+// WithClock makes the server use now for the time instead of time.Now, which decides the daily target
+func WithClock(now func() time.Time) Option {
+	return func(server *Server) {
+		server.now = now
+	}
+}
+
+func New(options ...Option) *Server {
+	server := Server{
+		sessionTTL: 24 * time.Hour,
+		now:        time.Now,
+		random:     mathrand.Intn,
+		sessions:   map[string]*session{},
+	}
+	WithWordLists(data.ValidTargets, data.ValidGuesses)(&server)
+	for _, option := range options {
+		option(&server)
+	}
+	if server.calendar == nil {
+		server.calendar = daily.New(daily.WithTargets(server.targets))
+	}
+
+	server.mux = http.NewServeMux()
+	server.mux.HandleFunc("POST /games", server.createGame)
+	server.mux.HandleFunc("GET /games/{id}", server.getGame)
+	server.mux.HandleFunc("DELETE /games/{id}", server.deleteGame)
+	server.mux.HandleFunc("POST /games/{id}/guesses", server.submitGuess)
+	server.mux.HandleFunc("GET /games/{id}/share", server.share)
+	return &server
+}
+
+func (this *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	this.mux.ServeHTTP(w, r)
+}
+
+// private
+
+func (this *Server) createGame(w http.ResponseWriter, r *http.Request) {
+	request := NewGameRequest{Mode: RandomMode, MaxNumGuesses: wordle.MaxNumGuesses}
+	if err := readJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rules := wordle.DefaultRules
+	rules.HardMode = request.HardMode
+	if request.MaxNumGuesses != 0 {
+		rules.MaxNumGuesses = request.MaxNumGuesses
+	}
+
+	if len(this.targets) == 0 {
+		writeError(w, http.StatusInternalServerError, errors.New("no targets"))
+		return
+	}
+	newSession := session{mode: request.Mode}
+	var target string
+	switch request.Mode {
+	case RandomMode, "":
+		newSession.mode = RandomMode
+		target = this.targets[this.random(len(this.targets))]
+	case SeededMode:
+		target = this.targets[mathrand.New(mathrand.NewSource(request.Seed)).Intn(len(this.targets))]
+	case DailyMode:
+		puzzleNumber, dailyTarget, err := this.calendar.Today(this.now())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		newSession.puzzleNumber, target = &puzzleNumber, dailyTarget
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown mode %q", request.Mode))
+		return
+	}
+	game, err := wordle.NewGame(target, this.words, rules)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	newSession.game = game
+
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.forgetExpiredSessions()
+	id := newSessionID()
+	newSession.lastUsed = this.now()
+	this.sessions[id] = &newSession
+	w.Header().Set("Location", "/games/"+id)
+	writeJSON(w, http.StatusCreated, newSession.state(id))
+}
+
+func (this *Server) getGame(w http.ResponseWriter, r *http.Request) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	id := r.PathValue("id")
+	session, ok := this.session(w, id)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, session.state(id))
+}
+
+func (this *Server) deleteGame(w http.ResponseWriter, r *http.Request) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := this.session(w, id); !ok {
+		return
+	}
+	delete(this.sessions, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (this *Server) submitGuess(w http.ResponseWriter, r *http.Request) {
+	var request GuessRequest
+	if err := readJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	id := r.PathValue("id")
+	session, ok := this.session(w, id)
+	if !ok {
+		return
+	}
+	_, err := session.game.Submit(request.Guess)
+	switch {
+	case errors.Is(err, wordle.ErrGameOver):
+		writeError(w, http.StatusConflict, err)
+	case err != nil:
+		writeError(w, http.StatusUnprocessableEntity, err)
+	default:
+		writeJSON(w, http.StatusOK, session.state(id))
+	}
+}
+
+func (this *Server) share(w http.ResponseWriter, r *http.Request) {
+	theme := wordle.DarkTheme
+	if name := r.URL.Query().Get("theme"); name != "" {
+		var ok bool
+		if theme, ok = wordle.ShareThemes[name]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unknown theme %q", name))
+			return
+		}
+	}
+
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	session, ok := this.session(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if session.game.Status() == wordle.GameInProgress {
+		writeError(w, http.StatusConflict, errors.New("the game is still in progress"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, session.game.Share(session.puzzleNumber).Format(theme)+"\n")
+}
+
+// session returns the session with the id, or writes a 404 if there isn't one. The lock has to be held.
+func (this *Server) session(w http.ResponseWriter, id string) (*session, bool) {
+	session, ok := this.sessions[id]
+	if !ok || this.now().Sub(session.lastUsed) > this.sessionTTL {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no game %q", id))
+		return nil, false
+	}
+	session.lastUsed = this.now()
+	return session, true
+}
+
+// forgetExpiredSessions deletes the sessions that haven't been used for the TTL. The lock has to be held.
+func (this *Server) forgetExpiredSessions() {
+	for id, session := range this.sessions {
+		if this.now().Sub(session.lastUsed) > this.sessionTTL {
+			delete(this.sessions, id)
+		}
+	}
+}
+
+func (this *session) state(id string) GameState {
+	state := GameState{
+		ID:           id,
+		Mode:         this.mode,
+		PuzzleNumber: this.puzzleNumber,
+		Rules:        this.game.Rules(),
+		Status:       this.game.Status(),
+		Turns:        this.game.Turns(),
+		GuessesLeft:  this.game.GuessesLeft(),
+	}
+	if state.Turns == nil {
+		state.Turns = []wordle.Turn{}
+	}
+	if state.Status != wordle.GameInProgress {
+		state.Target = this.game.Target()
+	}
+	return state
+}
+
+func newSessionID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, value any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Println("writing response:", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
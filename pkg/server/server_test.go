@@ -0,0 +1,196 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle"
+	"github.com/tliddle1/wordle/pkg/daily"
+)
+
+func TestServerFixture(t *testing.T) {
+	gunit.Run(new(ServerFixture), t)
+}
+
+type ServerFixture struct {
+	*gunit.Fixture
+	server *Server
+	now    time.Time
+}
+
+func (this *ServerFixture) Setup() {
+	this.now = time.Date(2021, time.June, 21, 12, 0, 0, 0, time.UTC)
+	this.server = New(
+		WithWordLists([]string{"angry", "crane", "crank"}, []string{"salet"}),
+		WithClock(func() time.Time { return this.now }),
+	)
+	this.server.random = func(n int) int { return n - 1 }
+}
+
+func (this *ServerFixture) do(method, path, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	this.server.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (this *ServerFixture) decode(recorder *httptest.ResponseRecorder) (state GameState) {
+	this.So(json.NewDecoder(recorder.Body).Decode(&state), should.BeNil)
+	return state
+}
+
+func (this *ServerFixture) createGame(body string) GameState {
+	recorder := this.do(http.MethodPost, "/games", body)
+	this.So(recorder.Code, should.Equal, http.StatusCreated)
+	state := this.decode(recorder)
+	this.So(recorder.Header().Get("Location"), should.Equal, "/games/"+state.ID)
+	return state
+}
+
+func (this *ServerFixture) TestWordListsAreNotWrittenTo() {
+	words := make([]string, 1, 2)
+	words[0] = "salet"
+	targets := []string{"crane"}
+	New(WithWordLists(targets, words[:1]))
+	this.So(words[:2], should.Resemble, []string{"salet", ""})
+}
+
+func (this *ServerFixture) TestPlayGame() {
+	state := this.createGame("")
+	this.So(state.Mode, should.Equal, RandomMode)
+	this.So(state.Status, should.Equal, wordle.GameInProgress)
+	this.So(state.Turns, should.BeEmpty)
+	this.So(state.GuessesLeft, should.Equal, 6)
+	this.So(state.Target, should.BeEmpty)
+
+	recorder := this.do(http.MethodPost, "/games/"+state.ID+"/guesses", `{"guess":"crane"}`)
+	this.So(recorder.Code, should.Equal, http.StatusOK)
+	state = this.decode(recorder)
+	this.So(state.Turns, should.Resemble, []wordle.Turn{{Guess: "crane", Pattern: wordle.CheckGuess("crank", "crane")}})
+	this.So(state.Target, should.BeEmpty)
+
+	state = this.decode(this.do(http.MethodPost, "/games/"+state.ID+"/guesses", `{"guess":"crank"}`))
+	this.So(state.Status, should.Equal, wordle.GameWon)
+	this.So(state.Target, should.Equal, "crank")
+	this.So(state.GuessesLeft, should.Equal, 0)
+
+	this.So(this.decode(this.do(http.MethodGet, "/games/"+state.ID, "")), should.Resemble, state)
+}
+
+func (this *ServerFixture) TestInvalidGuesses() {
+	state := this.createGame(`{"hardMode":true}`)
+	path := "/games/" + state.ID + "/guesses"
+	this.So(this.do(http.MethodPost, path, `{"guess":"zzzzz"}`).Code, should.Equal, http.StatusUnprocessableEntity)
+	this.So(this.do(http.MethodPost, path, `{"guess":"cranes"}`).Code, should.Equal, http.StatusUnprocessableEntity)
+	this.So(this.do(http.MethodPost, path, `{"gues":"crane"}`).Code, should.Equal, http.StatusBadRequest)
+	this.So(this.do(http.MethodPost, path, `{"guess":"crane"}`).Code, should.Equal, http.StatusOK)
+
+	recorder := this.do(http.MethodPost, path, `{"guess":"salet"}`)
+	this.So(recorder.Code, should.Equal, http.StatusUnprocessableEntity)
+	this.So(recorder.Body.String(), should.ContainSubstring, "1st letter must be C")
+}
+
+func (this *ServerFixture) TestGameOver() {
+	state := this.createGame(`{"maxNumGuesses":1}`)
+	path := "/games/" + state.ID + "/guesses"
+	state = this.decode(this.do(http.MethodPost, path, `{"guess":"salet"}`))
+	this.So(state.Status, should.Equal, wordle.GameLost)
+	this.So(state.Target, should.Equal, "crank")
+	this.So(this.do(http.MethodPost, path, `{"guess":"crank"}`).Code, should.Equal, http.StatusConflict)
+}
+
+func (this *ServerFixture) TestSeededGamesHaveTheSameTarget() {
+	first := this.createGame(`{"mode":"seeded","seed":42,"maxNumGuesses":1}`)
+	second := this.createGame(`{"mode":"seeded","seed":42,"maxNumGuesses":1}`)
+	first = this.decode(this.do(http.MethodPost, "/games/"+first.ID+"/guesses", `{"guess":"salet"}`))
+	second = this.decode(this.do(http.MethodPost, "/games/"+second.ID+"/guesses", `{"guess":"salet"}`))
+	this.So(first.Target, should.Equal, second.Target)
+	this.So(first.ID, should.NotEqual, second.ID)
+}
+
+func (this *ServerFixture) TestDaily() {
+	state := this.createGame(`{"mode":"daily"}`)
+	this.So(*state.PuzzleNumber, should.Equal, 2)
+	state = this.decode(this.do(http.MethodPost, "/games/"+state.ID+"/guesses", `{"guess":"crank"}`))
+	this.So(state.Status, should.Equal, wordle.GameWon)
+
+	this.now = this.now.Add(24 * time.Hour)
+	this.So(*this.createGame(`{"mode":"daily"}`).PuzzleNumber, should.Equal, 3)
+}
+
+func (this *ServerFixture) TestDailyPuzzleZero() {
+	this.now = daily.DefaultEpoch.Add(12 * time.Hour)
+	recorder := this.do(http.MethodPost, "/games", `{"mode":"daily"}`)
+	this.So(recorder.Body.String(), should.ContainSubstring, `"puzzleNumber":0`)
+	state := this.decode(recorder)
+	target, err := this.server.calendar.Target(0)
+	this.So(err, should.BeNil)
+	this.do(http.MethodPost, "/games/"+state.ID+"/guesses", `{"guess":"`+target+`"}`)
+	body, _ := io.ReadAll(this.do(http.MethodGet, "/games/"+state.ID+"/share", "").Body)
+	this.So(string(body), should.StartWith, "Wordle 0 1/6")
+}
+
+func (this *ServerFixture) TestOtherModesHaveNoPuzzleNumber() {
+	recorder := this.do(http.MethodPost, "/games", `{"mode":"random"}`)
+	this.So(recorder.Body.String(), should.NotContainSubstring, "puzzleNumber")
+}
+
+func (this *ServerFixture) TestShare() {
+	state := this.createGame(`{"mode":"daily"}`)
+	this.So(this.do(http.MethodGet, "/games/"+state.ID+"/share", "").Code, should.Equal, http.StatusConflict)
+	this.do(http.MethodPost, "/games/"+state.ID+"/guesses", `{"guess":"crank"}`)
+
+	recorder := this.do(http.MethodGet, "/games/"+state.ID+"/share?theme=light", "")
+	this.So(recorder.Code, should.Equal, http.StatusOK)
+	body, _ := io.ReadAll(recorder.Body)
+	this.So(string(body), should.Equal, "Wordle 2 1/6\n\n🟩🟩🟩🟩🟩\n")
+	this.So(this.do(http.MethodGet, "/games/"+state.ID+"/share?theme=neon", "").Code, should.Equal, http.StatusBadRequest)
+}
+
+func (this *ServerFixture) TestUnknownGame() {
+	this.So(this.do(http.MethodGet, "/games/nope", "").Code, should.Equal, http.StatusNotFound)
+	this.So(this.do(http.MethodPost, "/games/nope/guesses", `{"guess":"crane"}`).Code, should.Equal, http.StatusNotFound)
+}
+
+func (this *ServerFixture) TestUnknownMode() {
+	this.So(this.do(http.MethodPost, "/games", `{"mode":"hourly"}`).Code, should.Equal, http.StatusBadRequest)
+}
+
+func (this *ServerFixture) TestInvalidRules() {
+	this.So(this.do(http.MethodPost, "/games", `{"maxNumGuesses":-1}`).Code, should.Equal, http.StatusBadRequest)
+}
+
+func (this *ServerFixture) TestBodyTooLarge() {
+	server := httptest.NewServer(this.server)
+	defer server.Close()
+	body := `{"mode":"` + strings.Repeat("x", maxRequestSize) + `"}`
+	response, err := http.Post(server.URL+"/games", "application/json", strings.NewReader(body))
+	this.So(err, should.BeNil)
+	defer response.Body.Close()
+	this.So(response.StatusCode, should.Equal, http.StatusBadRequest)
+	this.So(response.Close, should.BeTrue) // the server closes the connection instead of reading the rest of the body
+}
+
+func (this *ServerFixture) TestDeleteGame() {
+	state := this.createGame("")
+	this.So(this.do(http.MethodDelete, "/games/"+state.ID, "").Code, should.Equal, http.StatusNoContent)
+	this.So(this.do(http.MethodGet, "/games/"+state.ID, "").Code, should.Equal, http.StatusNotFound)
+}
+
+func (this *ServerFixture) TestSessionsExpire() {
+	state := this.createGame("")
+	this.now = this.now.Add(23 * time.Hour)
+	this.So(this.do(http.MethodGet, "/games/"+state.ID, "").Code, should.Equal, http.StatusOK)
+	this.now = this.now.Add(25 * time.Hour)
+	this.So(this.do(http.MethodGet, "/games/"+state.ID, "").Code, should.Equal, http.StatusNotFound)
+
+	this.createGame("")
+	this.So(this.server.sessions, should.HaveLength, 1)
+}
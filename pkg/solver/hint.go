@@ -0,0 +1,110 @@
+package solver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	. "github.com/tliddle1/wordle"
+)
+
+// HintLevel is how much a hint gives away. Every level gives away more than the one before.
+type HintLevel int
+
+const (
+	CandidateCountHint HintLevel = iota + 1 // the number of words that can still be the target
+	LetterHint                              // a letter that is in the target but hasn't been found yet
+	GuessHint                               // the guess the solver would make
+	CandidatesHint                          // every word that can still be the target
+	MaxHintLevel       = CandidatesHint
+)
+
+// maxListedCandidates is the most candidates a CandidatesHint lists
+const maxListedCandidates = 50
+
+// Hint is help with the next guess
+type Hint struct {
+	Level      HintLevel
+	Candidates []string   // the words that can still be the target
+	Letter     rune       // for a LetterHint, the letter, or 0 if no letter that hasn't been found is in every candidate
+	NumHaving  int        // for a LetterHint, the number of candidates the letter is in
+	Suggestion Suggestion // for a GuessHint, the guess
+}
+
+func (this Hint) String() string {
+	switch this.Level {
+	case CandidateCountHint:
+		if len(this.Candidates) == 1 {
+			return "Only one word is left."
+		}
+		return fmt.Sprintf("%d words are left.", len(this.Candidates))
+	case LetterHint:
+		switch {
+		case this.Letter == 0:
+			return "Every letter in all of the words that are left has been found."
+		case this.NumHaving == len(this.Candidates):
+			return fmt.Sprintf("The word has %s.", strings.ToUpper(string(this.Letter)))
+		}
+		return fmt.Sprintf("%s is in %d of the %d words that are left.", strings.ToUpper(string(this.Letter)),
+			this.NumHaving, len(this.Candidates))
+	case GuessHint:
+		return fmt.Sprintf("Try %s (%.2f bits).", strings.ToUpper(this.Suggestion.Guess), this.Suggestion.ExpectedInfo)
+	default:
+		if len(this.Candidates) > maxListedCandidates {
+			return fmt.Sprintf("The words left are %s and %d more.",
+				strings.Join(this.Candidates[:maxListedCandidates], ", "), len(this.Candidates)-maxListedCandidates)
+		}
+		return fmt.Sprintf("The words left are %s.", strings.Join(this.Candidates, ", "))
+	}
+}
+
+// Hinter gives hints from what a ThomasSolver knows about a turn history
+type Hinter struct {
+	solver *ThomasSolver
+}
+
+// NewHinter returns a hinter whose solver has the options, like WithGameRules for the hints to follow hard mode. Without
+// WithPatternTable, like from CachedPatternTableOptions, the first hint computes the pattern table.
+func NewHinter(options ...ThomasSolverOption) *Hinter {
+	return &Hinter{solver: NewThomasSolver(options...)}
+}
+
+// Hint returns the hint of the level for the turn history. Levels above MaxHintLevel are MaxHintLevel. The error
+// wraps ErrInconsistentHistory if no target fits the history.
+func (this *Hinter) Hint(turnHistory []Turn, level HintLevel) (Hint, error) {
+	level = min(max(level, CandidateCountHint), MaxHintLevel)
+	if err := this.solver.startOver(turnHistory); err != nil {
+		return Hint{}, err
+	}
+	hint := Hint{Level: level, Candidates: this.solver.Candidates()}
+	switch level {
+	case LetterHint:
+		hint.Letter, hint.NumHaving = unfoundLetter(turnHistory, hint.Candidates)
+	case GuessHint:
+		hint.Suggestion = this.solver.suggest(1)[0]
+	}
+	return hint, nil
+}
+
+// unfoundLetter returns the letter that hasn't been found in the turn history that is in the most candidates, with the
+// number of candidates it is in. Ties go to the letter first in the alphabet.
+func unfoundLetter(turnHistory []Turn, candidates []string) (rune, int) {
+	found := NewConstraints(turnHistory).Present()
+	counts := make(map[rune]int)
+	for _, candidate := range candidates {
+		letters := []rune(candidate)
+		slices.Sort(letters)
+		for _, letter := range slices.Compact(letters) {
+			if !slices.Contains(found, letter) {
+				counts[letter]++
+			}
+		}
+	}
+	best, bestCount := rune(0), 0
+	for letter, count := range counts {
+		if count > bestCount || (count == bestCount && letter < best) {
+			best, bestCount = letter, count
+		}
+	}
+	return best, bestCount
+}
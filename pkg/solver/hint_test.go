@@ -0,0 +1,63 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	. "github.com/tliddle1/wordle"
+)
+
+func TestHintFixture(t *testing.T) {
+	gunit.Run(new(HintFixture), t)
+}
+
+type HintFixture struct {
+	*gunit.Fixture
+	Hinter *Hinter
+	Turns  []Turn
+}
+
+func (this *HintFixture) Setup() {
+	words := []string{"tree", "free", "flee", "glee", "gees", "bees", "even", "seen", "teen"}
+	this.Hinter = NewHinter(WithGameRules(Rules{WordLength: 4}), WithWordLists(words, nil))
+	this.Turns = []Turn{{Guess: "bees", Pattern: CheckGuess("free", "bees")}}
+}
+
+func (this *HintFixture) TestCandidateCount() {
+	hint, err := this.Hinter.Hint(this.Turns, CandidateCountHint)
+	this.So(err, should.BeNil)
+	this.So(hint.Candidates, should.Resemble, []string{"tree", "free", "flee", "glee", "even"})
+	this.So(hint.String(), should.Equal, "5 words are left.")
+}
+
+func (this *HintFixture) TestLetter() {
+	hint, err := this.Hinter.Hint(this.Turns, LetterHint)
+	this.So(err, should.BeNil)
+	this.So(hint.Letter, should.Equal, 'f')
+	this.So(hint.NumHaving, should.Equal, 2)
+	this.So(hint.String(), should.Equal, "F is in 2 of the 5 words that are left.")
+
+	hint, _ = this.Hinter.Hint(append(this.Turns, Turn{Guess: "glee", Pattern: CheckGuess("free", "glee")}), LetterHint)
+	this.So(hint.String(), should.Equal, "The word has R.")
+}
+
+func (this *HintFixture) TestGuess() {
+	hint, err := this.Hinter.Hint(this.Turns, GuessHint)
+	this.So(err, should.BeNil)
+	this.So(hint.Suggestion.Guess, should.Equal, "flee")
+	this.So(hint.String(), should.Equal, "Try FLEE (2.32 bits).")
+}
+
+func (this *HintFixture) TestCandidates() {
+	hint, err := this.Hinter.Hint(this.Turns, CandidatesHint+1)
+	this.So(err, should.BeNil)
+	this.So(hint.Level, should.Equal, CandidatesHint)
+	this.So(hint.String(), should.Equal, "The words left are tree, free, flee, glee, even.")
+}
+
+func (this *HintFixture) TestInconsistentHistory() {
+	_, err := this.Hinter.Hint([]Turn{{Guess: "bees", Pattern: CorrectPattern}, {Guess: "tree", Pattern: CorrectPattern}}, LetterHint)
+	this.So(errors.Is(err, ErrInconsistentHistory), should.BeTrue)
+}
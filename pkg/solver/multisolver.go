@@ -0,0 +1,117 @@
+package solver
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/tliddle1/wordle"
+)
+
+// ThomasMultiSolver plays multi-board games. It guesses the last candidate of any board that is down to one, and
+// otherwise the word with the most expected information summed over the unsolved boards.
+type ThomasMultiSolver struct {
+	solver *ThomasSolver
+	boards []board
+	err    error // why the last guess couldn't be made
+}
+
+type board struct {
+	validTargets       []string
+	validTargetIndices []int
+	numTurns           int // the number of turns from the board's history already applied to validTargets
+	solved             bool
+}
+
+// NewThomasMultiSolver returns a solver that takes the same options as a ThomasSolver
+func NewThomasMultiSolver(options ...ThomasSolverOption) *ThomasMultiSolver {
+	return &ThomasMultiSolver{solver: NewThomasSolver(options...)}
+}
+
+func (this *ThomasMultiSolver) Debug() bool {
+	return false
+}
+
+func (this *ThomasMultiSolver) Guess(boardHistories [][]Turn) string {
+	this.err = nil
+	if len(this.boards) != len(boardHistories) {
+		this.boards = make([]board, len(boardHistories))
+		this.solver.setData()
+		for i := range this.boards {
+			this.boards[i].validTargets = this.solver.validTargets
+			this.boards[i].validTargetIndices = this.solver.validTargetIndices
+		}
+	}
+	started := false
+	for i, turnHistory := range boardHistories {
+		this.boards[i].update(turnHistory, this.solver)
+		started = started || len(turnHistory) > 0
+	}
+	if !started {
+		guess := this.solver.Guess(nil)
+		this.err = this.solver.Err()
+		return guess
+	}
+
+	var unsolved []board
+	for i, board := range this.boards {
+		if !board.solved && len(board.validTargets) == 0 {
+			this.err = fmt.Errorf("board %d: %w", i+1, inconsistentHistory(boardHistories[i], this.solver.targets))
+			return ""
+		}
+		if board.solved {
+			continue
+		}
+		if len(board.validTargets) == 1 {
+			return board.validTargets[0]
+		}
+		unsolved = append(unsolved, board)
+	}
+	if len(unsolved) == 0 {
+		return ""
+	}
+	return this.maximizeTotalExpectedInformation(unsolved)
+}
+
+func (this *ThomasMultiSolver) Reset() {
+	this.boards = nil
+	this.err = nil
+}
+
+// Err returns an error wrapping ErrInconsistentHistory if no target fit a board's turn history the last guess was
+// asked for with, in which case the guess was "". It implements wordle.ErrorReporter.
+func (this *ThomasMultiSolver) Err() error {
+	return this.err
+}
+
+// private
+
+func (this *board) update(turnHistory []Turn, solver *ThomasSolver) {
+	for _, turn := range turnHistory[this.numTurns:] {
+		if turn.Pattern.IsCorrect() {
+			this.solved = true
+		}
+		this.validTargets, this.validTargetIndices = solver.filterTargets(this.validTargets, this.validTargetIndices, turn)
+	}
+	this.numTurns = len(turnHistory)
+}
+
+func (this *ThomasMultiSolver) maximizeTotalExpectedInformation(boards []board) string {
+	wg := sync.WaitGroup{}
+	wordPairChannel := make(chan guessExpectedValuePair, len(this.solver.guesses))
+	wordWithMaxExpectedValue := make(chan string)
+	go determineWordWithMaxExpectedInfo(wordPairChannel, wordWithMaxExpectedValue)
+	for _, word := range this.solver.guesses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			totalExpectedInfo := float64(0)
+			for _, board := range boards {
+				totalExpectedInfo += this.solver.expectedInfo(word, board.validTargets, board.validTargetIndices)
+			}
+			wordPairChannel <- guessExpectedValuePair{word, totalExpectedInfo}
+		}()
+	}
+	wg.Wait()
+	close(wordPairChannel)
+	return <-wordWithMaxExpectedValue
+}
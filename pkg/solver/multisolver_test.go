@@ -0,0 +1,79 @@
+package solver
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	. "github.com/tliddle1/wordle"
+)
+
+func TestMultiSolverFixture(t *testing.T) {
+	gunit.Run(new(MultiSolverFixture), t)
+}
+
+type MultiSolverFixture struct {
+	*gunit.Fixture
+	Solver    *ThomasMultiSolver
+	Evaluator *Evaluator
+}
+
+func (this *MultiSolverFixture) Setup() {
+	this.Solver = NewThomasMultiSolver()
+	this.Evaluator = NewEvaluator(WithOutput(io.Discard))
+}
+
+func (this *MultiSolverFixture) TestOpener() {
+	this.So(this.Solver.Guess(make([][]Turn, Quordle)), should.Equal, "soare")
+}
+
+func (this *MultiSolverFixture) TestGuessesLastCandidate() {
+	boards := [][]Turn{
+		{{Guess: "soare", Pattern: CheckGuess("angry", "soare")}},
+		{{Guess: "soare", Pattern: CheckGuess("fuzzy", "soare")}},
+	}
+	this.Solver.Guess(boards)
+	this.Solver.boards[1].validTargets = []string{"fuzzy"}
+	this.So(this.Solver.Guess(boards), should.Equal, "fuzzy")
+}
+
+func (this *MultiSolverFixture) TestSkipsSolvedBoards() {
+	boards := [][]Turn{
+		{{Guess: "soare", Pattern: CorrectPattern}},
+		{{Guess: "soare", Pattern: CheckGuess("angry", "soare")}},
+	}
+	guess := this.Solver.Guess(boards)
+	this.So(this.Solver.boards[0].solved, should.BeTrue)
+	this.So(guess, should.NotEqual, "soare")
+}
+
+func (this *MultiSolverFixture) TestSingleGame() {
+	game := this.Evaluator.PlayMultiGame([]string{"angry", "fuzzy"}, this.Solver)
+	this.So(game.Err, should.BeNil)
+	this.So(game.NumGuesses, should.BeLessThanOrEqualTo, DefaultRules.MaxNumMultiGuesses(Dordle))
+}
+
+func (this *MultiSolverFixture) TestReset() {
+	this.Solver.Guess([][]Turn{{{Guess: "soare", Pattern: CheckGuess("angry", "soare")}}})
+	this.So(this.Solver.boards, should.HaveLength, 1)
+	this.Solver.Reset()
+	this.So(this.Solver.boards, should.BeEmpty)
+}
+
+func (this *MultiSolverFixture) TestNoTargets() {
+	solver := NewThomasMultiSolver(WithWordLists([]string{}, nil))
+	this.So(solver.Guess(make([][]Turn, 2)), should.Equal, "")
+	this.So(errors.Is(solver.Err(), ErrInconsistentHistory), should.BeTrue)
+}
+
+func (this *MultiSolverFixture) TestInconsistentBoard() {
+	boards := [][]Turn{
+		{{Guess: "soare", Pattern: CheckGuess("angry", "soare")}},
+		{{Guess: "sheen", Pattern: Pattern{Gray, Gray, Gray, Yellow, Gray}}},
+	}
+	this.So(this.Solver.Guess(boards), should.Equal, "")
+	this.So(errors.Is(this.Solver.Err(), ErrInconsistentHistory), should.BeTrue)
+	this.So(this.Solver.Err().Error(), should.StartWith, "board 2: ")
+}
@@ -0,0 +1,62 @@
+package solver
+
+import (
+	"slices"
+	"sync"
+
+	. "github.com/tliddle1/wordle"
+)
+
+// maxSharedPatternTables is how many tables are kept for solvers to share. Tables for the full word lists take tens
+// of megabytes, so only the most recently used ones are kept; the solvers using a table that was dropped keep it.
+const maxSharedPatternTables = 4
+
+var (
+	sharedPatternTablesLock sync.Mutex
+	sharedPatternTables     []*sharedPatternTableEntry // the most recently used first
+)
+
+// sharedPatternTableEntry is the table for a pair of word lists, computed once by whoever asks for it first
+type sharedPatternTableEntry struct {
+	guesses, targets []string
+	once             sync.Once
+	table            *PatternTable
+}
+
+// sharedPatternTable returns the table for the word lists, computing it the first time the lists are seen so that
+// every solver in the process can share it. It returns nil if the words are too long for a table. Only the table being
+// asked for waits while it is computed.
+func sharedPatternTable(guesses, targets []string) *PatternTable {
+	entry := sharedPatternTableEntryFor(guesses, targets)
+	entry.once.Do(func() {
+		entry.table, _ = NewPatternTable(entry.guesses, entry.targets)
+	})
+	return entry.table
+}
+
+// sharedPatternTableEntryFor returns the entry for the word lists, moved to the front, adding one if there is none and
+// dropping the least recently used entries past maxSharedPatternTables
+func sharedPatternTableEntryFor(guesses, targets []string) *sharedPatternTableEntry {
+	sharedPatternTablesLock.Lock()
+	defer sharedPatternTablesLock.Unlock()
+	i := slices.IndexFunc(sharedPatternTables, func(entry *sharedPatternTableEntry) bool {
+		return slices.Equal(entry.guesses, guesses) && slices.Equal(entry.targets, targets)
+	})
+	var entry *sharedPatternTableEntry
+	if i >= 0 {
+		entry = sharedPatternTables[i]
+		sharedPatternTables = slices.Delete(sharedPatternTables, i, i+1)
+	} else {
+		entry = &sharedPatternTableEntry{guesses: slices.Clone(guesses), targets: slices.Clone(targets)}
+	}
+	sharedPatternTables = slices.Insert(sharedPatternTables, 0, entry)
+	if len(sharedPatternTables) > maxSharedPatternTables {
+		clear(sharedPatternTables[maxSharedPatternTables:])
+		sharedPatternTables = sharedPatternTables[:maxSharedPatternTables]
+	}
+	return entry
+}
+
+func tableMatches(table *PatternTable, guesses, targets []string) bool {
+	return slices.Equal(table.Guesses(), guesses) && slices.Equal(table.Targets(), targets)
+}
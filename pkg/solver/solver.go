@@ -1,21 +1,143 @@
 package solver
 
 import (
+	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 
 	. "github.com/tliddle1/wordle"
 	"github.com/tliddle1/wordle/data"
+	"github.com/tliddle1/wordle/pkg/set"
 )
 
+const defaultOpener = "soare"
+
 type ThomasSolver struct {
-	validTargets []string
-	validGuesses []string
+	targets            []string      // every word that can be the target
+	guesses            []string      // every word that can be guessed, including the targets
+	table              *PatternTable // the patterns of guesses against targets, nil if the words are too long for one
+	opener             string        // the first guess, which is the same every game
+	validTargets       []string
+	validTargetIndices []int // the index of every valid target in targets
+	validGuesses       []string
+	wordLength         int
+	hardMode           bool
+	err                error // why the last guess couldn't be made
+}
+
+// ThomasSolverOption configures a ThomasSolver
+type ThomasSolverOption func(*ThomasSolver)
+
+// WithHardModeGuesses makes the solver only guess words that use every hint it has been given (see wordle.CheckHardMode)
+func WithHardModeGuesses() ThomasSolverOption {
+	return func(solver *ThomasSolver) {
+		solver.hardMode = true
+	}
+}
+
+// WithGameRules makes the solver play by the rules' word length and hard mode
+func WithGameRules(rules Rules) ThomasSolverOption {
+	return func(solver *ThomasSolver) {
+		solver.wordLength = rules.WordLength
+		solver.hardMode = rules.HardMode
+	}
+}
+
+// WithWordLists replaces data.ValidTargets and data.ValidGuesses as the words the solver considers. Words that don't
+// have the solver's word length are left out.
+func WithWordLists(targets, guesses []string) ThomasSolverOption {
+	return func(solver *ThomasSolver) {
+		solver.targets = targets
+		solver.guesses = guesses
+	}
+}
+
+// WithDictionaryWords makes the solver consider the dictionary's words, and only guess words of its length
+func WithDictionaryWords(dictionary *Dictionary) ThomasSolverOption {
+	return func(solver *ThomasSolver) {
+		solver.targets = dictionary.Targets()
+		solver.guesses = dictionary.Guesses()
+		solver.wordLength = dictionary.WordLength()
+	}
+}
+
+// WithOpener makes the solver always start with opener instead of "soare"
+func WithOpener(opener string) ThomasSolverOption {
+	return func(solver *ThomasSolver) {
+		solver.opener = opener
+	}
+}
+
+// WithPatternTable makes the solver look patterns up in table, like one from LoadThomasPatternTable, instead of
+// computing its own. The table is only used if it was computed for the solver's word lists.
+func WithPatternTable(table *PatternTable) ThomasSolverOption {
+	return func(solver *ThomasSolver) {
+		solver.table = table
+	}
 }
 
-func NewThomasSolver() *ThomasSolver {
-	solver := ThomasSolver{}
+func NewThomasSolver(options ...ThomasSolverOption) *ThomasSolver {
+	solver := newThomasSolverWordLists(options...)
+	if solver.table != nil && !tableMatches(solver.table, solver.guesses, solver.targets) {
+		solver.table = nil
+	}
+	if solver.table == nil {
+		solver.table = sharedPatternTable(solver.guesses, solver.targets)
+	}
+	if solver.opener == "" && slices.Contains(solver.guesses, defaultOpener) {
+		solver.opener = defaultOpener
+	}
 	solver.setData()
+	return solver
+}
+
+// LoadThomasPatternTable returns the table a ThomasSolver with these options would use from the cache in dir,
+// computing and saving it if needed (see wordle.LoadPatternTable). Pass it to the solvers with WithPatternTable.
+func LoadThomasPatternTable(dir string, options ...ThomasSolverOption) (*PatternTable, error) {
+	solver := newThomasSolverWordLists(options...)
+	return LoadPatternTable(dir, solver.guesses, solver.targets)
+}
+
+// CachedPatternTableOptions returns the options with WithPatternTable added for the table LoadThomasPatternTable loads
+// for them from the cache in dir. With no dir the options are returned as they are. If the table can't be loaded they
+// are returned as they are with the error, and the solvers compute the table themselves; if it was computed but
+// couldn't be saved the table is still added.
+func CachedPatternTableOptions(dir string, options []ThomasSolverOption) ([]ThomasSolverOption, error) {
+	if dir == "" {
+		return options, nil
+	}
+	table, err := LoadThomasPatternTable(dir, options...)
+	if table == nil {
+		return options, err
+	}
+	return append(slices.Clip(options), WithPatternTable(table)), err
+}
+
+// DefaultCacheDir returns where the commands cache pattern tables unless they are given another directory, which is
+// wordle in the user's cache directory, or "" if there is none
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wordle")
+}
+
+// newThomasSolverWordLists returns a solver that has its options and word lists, but no pattern table or data yet
+func newThomasSolverWordLists(options ...ThomasSolverOption) *ThomasSolver {
+	solver := ThomasSolver{
+		targets:    data.ValidTargets,
+		guesses:    data.ValidGuesses,
+		wordLength: WordLength,
+	}
+	for _, option := range options {
+		option(&solver)
+	}
+	solver.targets = filterWordLength(solver.targets, solver.wordLength)
+	solver.guesses = filterWordLength(withTargets(solver.guesses, solver.targets), solver.wordLength)
 	return &solver
 }
 
@@ -24,10 +146,25 @@ func (this *ThomasSolver) Debug() bool {
 }
 
 func (this *ThomasSolver) Guess(turnHistory []Turn) string {
+	this.err = nil
 	if len(turnHistory) == 0 {
-		return "soare"
+		if len(this.targets) == 0 { // like when no word in the lists has the rules' word length
+			this.err = inconsistentHistory(turnHistory, this.targets)
+			return ""
+		}
+		if this.opener == "" {
+			this.opener = this.maximizeExpectedInformation()
+		}
+		return this.opener
 	}
 	this.updateValidTargets(turnHistory)
+	if len(this.validTargets) == 0 {
+		this.err = inconsistentHistory(turnHistory, this.targets)
+		return ""
+	}
+	if this.hardMode {
+		this.updateValidGuesses(turnHistory)
+	}
 	guess := this.maximizeExpectedInformation()
 	return guess
 }
@@ -36,11 +173,55 @@ func (this *ThomasSolver) Reset() {
 	this.setData()
 }
 
+// Err returns an error wrapping ErrInconsistentHistory if no target fit the turn history the last guess was asked for
+// with, in which case the guess was "". It implements wordle.ErrorReporter.
+func (this *ThomasSolver) Err() error {
+	return this.err
+}
+
 // private
 
 func (this *ThomasSolver) setData() {
-	this.validTargets = data.ValidTargets
-	this.validGuesses = append(data.ValidGuesses, data.ValidTargets...)
+	this.validTargets = this.targets
+	this.validTargetIndices = make([]int, len(this.targets))
+	for i := range this.validTargetIndices {
+		this.validTargetIndices[i] = i
+	}
+	this.validGuesses = this.guesses
+}
+
+// withTargets returns the guesses followed by the targets that aren't among them
+func withTargets(guesses, targets []string) []string {
+	words := set.Set[string]{}
+	for _, guess := range guesses {
+		words.Add(guess)
+	}
+	guesses = slices.Clone(guesses)
+	for _, target := range targets {
+		if !words.Contains(target) {
+			guesses = append(guesses, target)
+			words.Add(target)
+		}
+	}
+	return guesses
+}
+
+// inconsistentHistory returns why no target is left after the turn history
+func inconsistentHistory(turnHistory []Turn, targets []string) error {
+	if err := ValidateHistory(turnHistory, targets); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: the turns don't follow the ones the solver was given since it was reset", ErrInconsistentHistory)
+}
+
+func filterWordLength(words []string, wordLength int) []string {
+	var filtered []string
+	for _, word := range words {
+		if NumLetters(word) == wordLength {
+			filtered = append(filtered, word)
+		}
+	}
+	return filtered
 }
 
 func (this *ThomasSolver) updateValidTargets(turnHistory []Turn) {
@@ -48,13 +229,36 @@ func (this *ThomasSolver) updateValidTargets(turnHistory []Turn) {
 		return
 	}
 	lastTurn := turnHistory[len(turnHistory)-1]
+	this.validTargets, this.validTargetIndices = this.filterTargets(this.validTargets, this.validTargetIndices, lastTurn)
+}
+
+// filterTargets returns the targets, and their indices, that would have given the turn's pattern
+func (this *ThomasSolver) filterTargets(targets []string, indices []int, turn Turn) ([]string, []int) {
 	var newTargets []string
-	for _, target := range this.validTargets {
-		if this.isValidTarget(target, lastTurn) {
+	var newIndices []int
+	guess, inTable := -1, false
+	if this.table != nil {
+		guess, inTable = this.table.GuessIndex(turn.Guess)
+	}
+	pattern := byte(turn.Pattern.Index())
+	for i, target := range targets {
+		if (inTable && this.table.PatternIndex(guess, indices[i]) == pattern) || (!inTable && this.isValidTarget(target, turn)) {
 			newTargets = append(newTargets, target)
+			newIndices = append(newIndices, indices[i])
+		}
+	}
+	return newTargets, newIndices
+}
+
+func (this *ThomasSolver) updateValidGuesses(turnHistory []Turn) {
+	var newGuesses []string
+	constraints := NewConstraints(turnHistory)
+	for _, guess := range this.validGuesses {
+		if constraints.CheckHardMode(guess) == nil {
+			newGuesses = append(newGuesses, guess)
 		}
 	}
-	this.validTargets = newTargets
+	this.validGuesses = newGuesses
 }
 
 func (this *ThomasSolver) isValidTarget(word string, turn Turn) bool {
@@ -62,6 +266,9 @@ func (this *ThomasSolver) isValidTarget(word string, turn Turn) bool {
 }
 
 func (this *ThomasSolver) maximizeExpectedInformation() string {
+	if len(this.validTargets) == 0 {
+		return ""
+	}
 	if len(this.validTargets) <= 2 {
 		return this.validTargets[0]
 	}
@@ -86,15 +293,47 @@ func (this *ThomasSolver) calculateExpectedInfoAndSendToCompareChannel(out chan
 }
 
 func (this *ThomasSolver) calculateExpectedInfo(word string) float64 {
+	return this.expectedInfo(word, this.validTargets, this.validTargetIndices)
+}
+
+// expectedInfo returns the entropy, in bits, of the pattern the word would get if the target were one of targets,
+// whose indices are given so the patterns can be looked up in the table
+func (this *ThomasSolver) expectedInfo(word string, targets []string, indices []int) float64 {
+	if this.table == nil {
+		return expectedInfo(word, targets)
+	}
+	guess, ok := this.table.GuessIndex(word)
+	if !ok {
+		return expectedInfo(word, targets)
+	}
+	row := this.table.Row(guess)
+	var possiblePatterns [256]int
+	for _, target := range indices {
+		possiblePatterns[row[target]]++
+	}
+
+	expectedInfo := float64(0)
+	for _, count := range possiblePatterns {
+		if count > 0 {
+			probabilityOfPattern := float64(count) / float64(len(indices))
+			expectedInfo += -probabilityOfPattern * math.Log2(probabilityOfPattern)
+		}
+	}
+	return expectedInfo
+}
+
+// expectedInfo returns the entropy, in bits, of the pattern the word would get if the target were one of targets,
+// computing every pattern
+func expectedInfo(word string, targets []string) float64 {
 	possiblePatterns := make(map[Pattern]int)
-	for _, possibleTarget := range this.validTargets {
+	for _, possibleTarget := range targets {
 		possiblePattern := CheckGuess(possibleTarget, word)
 		possiblePatterns[possiblePattern]++
 	}
 
 	expectedInfo := float64(0)
 	for _, count := range possiblePatterns {
-		probabilityOfPattern := float64(count) / float64(len(this.validTargets))
+		probabilityOfPattern := float64(count) / float64(len(targets))
 		expectedInfo += -probabilityOfPattern * math.Log2(probabilityOfPattern)
 	}
 	return expectedInfo
@@ -1,6 +1,10 @@
 package solver
 
 import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/smarty/assertions/should"
@@ -76,6 +80,146 @@ func (this *SolverFixture) TestSingleGame() {
 	this.So(numGuesses, should.BeLessThanOrEqualTo, MaxNumGuesses)
 }
 
+func (this *SolverFixture) TestSingleGameHardMode() {
+	evaluator := NewEvaluator(WithHardMode())
+	numGuesses, err := evaluator.PlayGame("angry", NewThomasSolver(WithHardModeGuesses()))
+	this.So(err, should.BeNil)
+	this.So(numGuesses, should.BeLessThanOrEqualTo, MaxNumGuesses)
+}
+
+func (this *SolverFixture) TestSingleGameRules() {
+	rules := Rules{WordLength: 4, MaxNumGuesses: 8}
+	words := []string{"tree", "free", "flee", "glee", "gees", "bees", "even", "seen", "teen", "trees"}
+	evaluator := NewEvaluator(WithRules(rules), WithTargets(words))
+	solver := NewThomasSolver(WithGameRules(rules), WithWordLists(words, nil))
+	this.So(solver.validGuesses, should.NotContain, "trees")
+	for _, target := range evaluator.Targets() {
+		solver.Reset()
+		numGuesses, err := evaluator.PlayGame(target, solver)
+		this.So(err, should.BeNil)
+		this.So(numGuesses, should.BeLessThanOrEqualTo, rules.MaxNumGuesses)
+	}
+}
+
+func (this *SolverFixture) TestSingleGameDictionary() {
+	dictionary, err := NewDictionary([]string{"tree", "free", "flee", "glee"}, []string{"tree", "free", "flee", "glee", "even", "teen"})
+	this.So(err, should.BeNil)
+	evaluator := NewEvaluator(WithDictionary(dictionary), WithOutput(io.Discard))
+	solver := NewThomasSolver(WithDictionaryWords(dictionary))
+	this.So(solver.validGuesses, should.Resemble, dictionary.Guesses())
+	report, err := evaluator.EvaluateSolver(solver)
+	this.So(err, should.BeNil)
+	this.So(report.WinRate, should.Equal, 1)
+}
+
+func (this *SolverFixture) TestSingleGameLetters() {
+	words := []string{"ñandú", "ñandu", "árbol", "arbol", "gañir", "niñez", "cañón"}
+	dictionary, err := NewDictionary(words, words)
+	this.So(err, should.BeNil)
+	evaluator := NewEvaluator(WithDictionary(dictionary), WithHardMode(), WithOutput(io.Discard))
+	solver := NewThomasSolver(WithDictionaryWords(dictionary), WithHardModeGuesses())
+	report, err := evaluator.EvaluateSolver(solver)
+	this.So(err, should.BeNil)
+	this.So(report.WinRate, should.Equal, 1)
+}
+
+func (this *SolverFixture) TestOpener() {
+	this.So(this.Solver.Guess(nil), should.Equal, "soare")
+	this.So(NewThomasSolver(WithOpener("salet")).Guess(nil), should.Equal, "salet")
+}
+
+func (this *SolverFixture) TestPatternTable() {
+	words := []string{"tree", "free", "flee", "glee"}
+	table, err := NewPatternTable(words, words)
+	this.So(err, should.BeNil)
+	solver := NewThomasSolver(WithGameRules(Rules{WordLength: 4}), WithWordLists(words, nil), WithPatternTable(table))
+	this.So(solver.table == table, should.BeTrue)
+
+	// Tables are compared as pointers, since the assertions would diff the printed tables if they failed
+	solver = NewThomasSolver(WithPatternTable(table))
+	this.So(solver.table == table, should.BeFalse)
+	this.So(solver.table.Targets(), should.Resemble, solver.targets)
+}
+
+func (this *SolverFixture) TestCachedPatternTableOptions() {
+	words := []string{"tree", "free", "flee", "glee"}
+	options := []ThomasSolverOption{WithGameRules(Rules{WordLength: 4}), WithWordLists(words, nil)}
+	uncached, err := CachedPatternTableOptions("", options)
+	this.So(err, should.BeNil)
+	this.So(uncached, should.HaveLength, 2)
+
+	dir, err := os.MkdirTemp("", "solver")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	cached, err := CachedPatternTableOptions(dir, options)
+	this.So(err, should.BeNil)
+	this.So(cached, should.HaveLength, 3)
+	this.So(NewThomasSolver(cached...).table.Targets(), should.Resemble, words)
+	entries, _ := os.ReadDir(dir)
+	this.So(entries, should.HaveLength, 1)
+}
+
+func (this *SolverFixture) TestCachedPatternTableOptionsReadOnly() {
+	words := []string{"crane", "salet", "angry"}
+	options := []ThomasSolverOption{WithWordLists(words, nil)}
+	dir, err := os.MkdirTemp("", "wordle-pattern-table")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	this.So(os.WriteFile(file, nil, 0o644), should.BeNil)
+	cached, err := CachedPatternTableOptions(filepath.Join(file, "cache"), options)
+	this.So(err, should.NotBeNil)
+	this.So(cached, should.HaveLength, 2)
+	this.So(NewThomasSolver(cached...).table.Targets(), should.Resemble, words)
+}
+
+func (this *SolverFixture) TestSharedPatternTable() {
+	this.So(NewThomasSolver().table == this.Solver.table, should.BeTrue)
+}
+
+func (this *SolverFixture) TestNoPatternTableForLongWords() {
+	words := []string{"banana", "cabana", "ananas"}
+	solver := NewThomasSolver(WithGameRules(Rules{WordLength: 6}), WithWordLists(words, nil))
+	this.So(solver.table, should.BeNil)
+	solver.updateValidTargets([]Turn{{Guess: "banana", Pattern: CheckGuess("cabana", "banana")}})
+	this.So(solver.validTargets, should.Resemble, []string{"cabana"})
+}
+
+func (this *SolverFixture) TestInconsistentHistory() {
+	turns := []Turn{
+		{Guess: "soare", Pattern: CheckGuess("sheen", "soare")},
+		{Guess: "siren", Pattern: Pattern{Green, Gray, Gray, Yellow, Green}},
+	}
+	this.So(this.Solver.Guess(turns[:1]), should.NotEqual, "")
+	this.So(this.Solver.Err(), should.BeNil)
+	this.So(this.Solver.Guess(turns), should.Equal, "")
+	var err *InconsistentHistoryError
+	this.So(errors.As(this.Solver.Err(), &err), should.BeTrue)
+	this.So(err.Turns, should.Resemble, []int{0, 1})
+
+	this.Solver.Reset()
+	this.So(this.Solver.Guess(turns[:1]), should.NotEqual, "")
+	this.So(this.Solver.Err(), should.BeNil)
+}
+
+func (this *SolverFixture) TestNoTargets() {
+	for _, solver := range []*ThomasSolver{
+		NewThomasSolver(WithGameRules(Rules{WordLength: 6, MaxNumGuesses: 6})),
+		NewThomasSolver(WithWordLists([]string{}, nil)),
+	} {
+		this.So(solver.Guess(nil), should.Equal, "")
+		this.So(errors.Is(solver.Err(), ErrInconsistentHistory), should.BeTrue)
+		this.So(solver.maximizeExpectedInformation(), should.Equal, "")
+	}
+}
+
+func (this *SolverFixture) TestInconsistentHistoryEndsTheGame() {
+	// The solver doesn't know the target, so no word it knows fits once it has guessed
+	solver := NewThomasSolver(WithWordLists([]string{"crane", "salet", "angry"}, nil))
+	_, err := this.Evaluator.PlayGame("sheen", solver)
+	this.So(errors.Is(err, ErrInconsistentHistory), should.BeTrue)
+}
+
 func (this *SolverFixture) TestUpdateValidTargetsNoOp() {
 	preUpdateLength := len(this.Solver.validTargets)
 	this.Solver.updateValidTargets([]Turn{})
@@ -89,3 +233,20 @@ func (this *SolverFixture) TestReset() {
 	this.Solver.Reset()
 	this.So(this.Solver.validTargets, should.HaveLength, preUpdateLength)
 }
+
+func TestSharedPatternTablesAreBounded(t *testing.T) {
+	words := []string{"crane", "salet", "angry", "sheen", "siren"}
+	var tables []*PatternTable
+	for i := range maxSharedPatternTables + 1 {
+		tables = append(tables, sharedPatternTable(words[:i+1], words[:i+1]))
+	}
+	if sharedPatternTable(words[:2], words[:2]) != tables[1] {
+		t.Error("a recently used table was computed again")
+	}
+	if sharedPatternTable(words[:1], words[:1]) == tables[0] {
+		t.Error("the least recently used table was kept")
+	}
+	if len(sharedPatternTables) != maxSharedPatternTables {
+		t.Errorf("%d tables were kept, want %d", len(sharedPatternTables), maxSharedPatternTables)
+	}
+}
@@ -0,0 +1,82 @@
+package solver
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	. "github.com/tliddle1/wordle"
+)
+
+// Suggestion is a word to guess next with the information it is expected to give
+type Suggestion struct {
+	Guess        string
+	ExpectedInfo float64 // the entropy, in bits, of the pattern the guess will get
+	Candidate    bool    // whether the guess can be the target, which makes it a chance to win right away
+}
+
+// Suggest returns the n guesses with the most expected information for the turn history, best first. Guesses that
+// are as good as each other are in order of candidates first and then alphabetically. Unlike Guess, the history
+// doesn't have to follow the one Suggest or Guess was last given, the solver starts over from it. The error wraps
+// ErrInconsistentHistory if no target fits the history.
+func (this *ThomasSolver) Suggest(turnHistory []Turn, n int) ([]Suggestion, error) {
+	if err := this.startOver(turnHistory); err != nil {
+		return nil, err
+	}
+	return this.suggest(n), nil
+}
+
+// suggest returns the n guesses with the most expected information for the turn history the solver was last given
+func (this *ThomasSolver) suggest(n int) []Suggestion {
+	candidates := make(map[string]bool, len(this.validTargets))
+	for _, target := range this.validTargets {
+		candidates[target] = true
+	}
+	suggestions := make([]Suggestion, len(this.validGuesses))
+	wg := sync.WaitGroup{}
+	for i, word := range this.validGuesses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			suggestions[i] = Suggestion{Guess: word, ExpectedInfo: this.calculateExpectedInfo(word), Candidate: candidates[word]}
+		}()
+	}
+	wg.Wait()
+	slices.SortFunc(suggestions, compareSuggestions)
+	return suggestions[:min(n, len(suggestions))]
+}
+
+// startOver narrows the targets and guesses down for the turn history from scratch
+func (this *ThomasSolver) startOver(turnHistory []Turn) error {
+	this.setData()
+	for i := range turnHistory {
+		this.updateValidTargets(turnHistory[:i+1])
+	}
+	if len(this.validTargets) == 0 {
+		return inconsistentHistory(turnHistory, this.targets)
+	}
+	if this.hardMode {
+		this.updateValidGuesses(turnHistory)
+	}
+	return nil
+}
+
+// Candidates returns the words that can still be the target after the turn history Suggest or Guess was last given
+func (this *ThomasSolver) Candidates() []string {
+	return slices.Clone(this.validTargets)
+}
+
+func compareSuggestions(a, b Suggestion) int {
+	switch {
+	case math.Abs(a.ExpectedInfo-b.ExpectedInfo) >= .00000001 && a.ExpectedInfo > b.ExpectedInfo:
+		return -1
+	case math.Abs(a.ExpectedInfo-b.ExpectedInfo) >= .00000001:
+		return 1
+	case a.Candidate != b.Candidate && a.Candidate:
+		return -1
+	case a.Candidate != b.Candidate:
+		return 1
+	}
+	return strings.Compare(a.Guess, b.Guess)
+}
@@ -0,0 +1,67 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	. "github.com/tliddle1/wordle"
+)
+
+func TestSuggestFixture(t *testing.T) {
+	gunit.Run(new(SuggestFixture), t)
+}
+
+type SuggestFixture struct {
+	*gunit.Fixture
+	Solver *ThomasSolver
+}
+
+func (this *SuggestFixture) Setup() {
+	this.Solver = NewThomasSolver()
+}
+
+func (this *SuggestFixture) TestOpeners() {
+	suggestions, err := this.Solver.Suggest(nil, 3)
+	this.So(err, should.BeNil)
+	this.So(suggestions, should.HaveLength, 3)
+	this.So(suggestions[0].Guess, should.Equal, "soare")
+	this.So(suggestions[0].ExpectedInfo, should.AlmostEqual, 5.8852027442927)
+	this.So(suggestions[1].ExpectedInfo, should.BeLessThanOrEqualTo, suggestions[0].ExpectedInfo)
+	this.So(this.Solver.Candidates(), should.HaveLength, len(this.Solver.targets))
+}
+
+func (this *SuggestFixture) TestAgreesWithGuess() {
+	turns := []Turn{{Guess: "soare", Pattern: CheckGuess("angry", "soare")}}
+	suggestions, err := this.Solver.Suggest(turns, 1)
+	this.So(err, should.BeNil)
+	this.So(suggestions[0].Guess, should.Equal, NewThomasSolver().Guess(turns))
+}
+
+func (this *SuggestFixture) TestStartsOverFromHistory() {
+	this.Solver.Suggest([]Turn{{Guess: "soare", Pattern: CheckGuess("angry", "soare")}}, 1)
+	turns := []Turn{{Guess: "soare", Pattern: CheckGuess("sheen", "soare")}}
+	this.Solver.Suggest(turns, 1)
+	this.So(this.Solver.Candidates(), should.Contain, "sheen")
+	this.So(this.Solver.Candidates(), should.NotContain, "angry")
+}
+
+func (this *SuggestFixture) TestCandidatesFirstOnTies() {
+	words := []string{"tree", "free", "flee", "glee"}
+	solver := NewThomasSolver(WithGameRules(Rules{WordLength: 4}), WithWordLists(words, nil))
+	suggestions, err := solver.Suggest([]Turn{{Guess: "glee", Pattern: CheckGuess("free", "glee")}}, 10)
+	this.So(err, should.BeNil)
+	this.So(solver.Candidates(), should.Resemble, []string{"tree", "free"})
+	this.So(suggestions, should.Resemble, []Suggestion{
+		{Guess: "free", ExpectedInfo: 1, Candidate: true},
+		{Guess: "tree", ExpectedInfo: 1, Candidate: true},
+		{Guess: "flee", ExpectedInfo: 1},
+		{Guess: "glee", ExpectedInfo: 0},
+	})
+}
+
+func (this *SuggestFixture) TestInconsistentHistory() {
+	_, err := this.Solver.Suggest([]Turn{{Guess: "sheen", Pattern: Pattern{Gray, Gray, Gray, Yellow, Gray}}}, 3)
+	this.So(errors.Is(err, ErrInconsistentHistory), should.BeTrue)
+}
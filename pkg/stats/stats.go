@@ -0,0 +1,284 @@
+// Package stats keeps a player's finished games in a JSON file, for the number played, streaks and the guess
+// distribution the official app shows after every game
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tliddle1/wordle"
+)
+
+var (
+	ErrInvalidStats = errors.New("invalid stats file")
+	ErrLocked       = errors.New("stats file is locked by another game")
+)
+
+const (
+	lockTimeout       = 15 * time.Second
+	staleLockAge      = 10 * time.Second // a lock this old was left behind by a game that crashed
+	lockRetryInterval = 20 * time.Millisecond
+	maxBarLength      = 30
+)
+
+// Record is a finished game
+type Record struct {
+	Time          time.Time `json:"time"`
+	PuzzleNumber  *int      `json:"puzzleNumber,omitempty"` // the daily puzzle the game was, nil if it wasn't one
+	Target        string    `json:"target"`
+	NumGuesses    int       `json:"numGuesses"`
+	Won           bool      `json:"won"`
+	HardMode      bool      `json:"hardMode,omitempty"`
+	MaxNumGuesses int       `json:"maxNumGuesses,omitempty"` // the number of guesses allowed, 0 in older records
+	Hints         int       `json:"hints,omitempty"`         // the number of hints the player asked for
+}
+
+// NewRecord returns the record of the game, which should be over. It was the daily puzzle with the number unless that
+// is nil.
+func NewRecord(game *wordle.Game, puzzleNumber *int, now time.Time) Record {
+	return Record{
+		Time:          now,
+		PuzzleNumber:  puzzleNumber,
+		Target:        game.Target(),
+		NumGuesses:    len(game.Turns()),
+		Won:           game.Status() == wordle.GameWon,
+		HardMode:      game.Rules().HardMode,
+		MaxNumGuesses: game.Rules().MaxNumGuesses,
+	}
+}
+
+// Stats is every game a player has finished, in the order they were played
+type Stats struct {
+	Games []Record `json:"games"`
+}
+
+// Played returns the number of games played
+func (this *Stats) Played() int {
+	return len(this.Games)
+}
+
+// WinRate returns the fraction of games won, 0 if none were played
+func (this *Stats) WinRate() float64 {
+	if len(this.Games) == 0 {
+		return 0
+	}
+	won := 0
+	for _, game := range this.Games {
+		if game.Won {
+			won++
+		}
+	}
+	return float64(won) / float64(len(this.Games))
+}
+
+// CurrentStreak returns the number of games won since the last one that was lost
+func (this *Stats) CurrentStreak() int {
+	streak := 0
+	for i := len(this.Games) - 1; i >= 0 && this.Games[i].Won; i-- {
+		streak++
+	}
+	return streak
+}
+
+// MaxStreak returns the most games won in a row
+func (this *Stats) MaxStreak() int {
+	streak, maxStreak := 0, 0
+	for _, game := range this.Games {
+		if game.Won {
+			streak++
+			maxStreak = max(maxStreak, streak)
+		} else {
+			streak = 0
+		}
+	}
+	return maxStreak
+}
+
+// HintsUsed returns the number of hints asked for in every game
+func (this *Stats) HintsUsed() int {
+	hints := 0
+	for _, game := range this.Games {
+		hints += game.Hints
+	}
+	return hints
+}
+
+// Distribution returns the number of games won in every number of guesses, where index 0 is for games won in 1 guess.
+// It goes up to the most guesses any game allowed, or took for records that don't say how many they allowed.
+func (this *Stats) Distribution() []int {
+	numRows := 0
+	for _, game := range this.Games {
+		numRows = max(numRows, game.MaxNumGuesses, game.NumGuesses)
+	}
+	distribution := make([]int, numRows)
+	for _, game := range this.Games {
+		if game.Won && game.NumGuesses > 0 {
+			distribution[game.NumGuesses-1]++
+		}
+	}
+	return distribution
+}
+
+// String returns the stats like the official app shows them, with the number of hints used if there were any and the
+// bar of the last game's number of guesses marked if it was won:
+//
+//	Played 12 · Win % 92 · Current streak 5 · Max streak 7
+//
+//	Guess distribution
+//	1 | 0
+//	2 | █ 1
+//	3 | ██████████ 5 ←
+func (this *Stats) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Played %d · Win %% %.0f · Current streak %d · Max streak %d",
+		this.Played(), this.WinRate()*100, this.CurrentStreak(), this.MaxStreak())
+	if hints := this.HintsUsed(); hints > 0 {
+		fmt.Fprintf(&builder, " · Hints used %d", hints)
+	}
+	builder.WriteString("\n\nGuess distribution\n")
+	distribution := this.Distribution()
+	mostGames := 0
+	for _, count := range distribution {
+		mostGames = max(mostGames, count)
+	}
+	last := 0
+	if n := len(this.Games); n > 0 && this.Games[n-1].Won {
+		last = this.Games[n-1].NumGuesses
+	}
+	for i, count := range distribution {
+		bar := ""
+		if count > 0 {
+			bar = strings.Repeat("█", max(1, count*maxBarLength/mostGames)) + " "
+		}
+		marker := ""
+		if i+1 == last {
+			marker = " ←"
+		}
+		fmt.Fprintf(&builder, "%d | %s%d%s\n", i+1, bar, count, marker)
+	}
+	return builder.String()
+}
+
+// DefaultPath returns where stats are kept unless another file is given, which is wordle/stats.json in
+// $XDG_DATA_HOME, or in ~/.local/share if it isn't set
+func DefaultPath() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "wordle", "stats.json")
+}
+
+// Load reads the stats in the file at path, which are empty if there is no file yet
+func Load(path string) (*Stats, error) {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Stats{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stats Stats
+	if err = json.Unmarshal(contents, &stats); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidStats, path, err)
+	}
+	return &stats, nil
+}
+
+// Add records the game in the file at path and returns the stats with it. Games finishing at the same time take turns
+// through a lock file next to it, and the file is replaced in one step, so it is never left half written. A file that
+// can't be read is left as it is and the error wraps ErrInvalidStats.
+func Add(path string, record Record) (*Stats, error) {
+	unlock, err := lock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	stats, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	stats.Games = append(stats.Games, record)
+	contents, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = writeFile(path, append(contents, '\n')); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// lock creates the lock file for path, waiting for another game to remove it if it exists, and returns the function
+// that removes it
+func lock(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			created, err := file.Stat()
+			_ = file.Close()
+			if err != nil {
+				_ = os.Remove(lockPath)
+				return nil, err
+			}
+			return func() { removeIfUnchanged(lockPath, created) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			removeIfUnchanged(lockPath, info)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrLocked, lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// removeIfUnchanged removes the file at path if it is still the file info is about and hasn't been modified since. A
+// game that finds a stale lock, or whose own lock was taken for a stale one, doesn't remove the lock another game has
+// created in its place.
+func removeIfUnchanged(path string, info fs.FileInfo) {
+	current, err := os.Stat(path)
+	if err == nil && os.SameFile(info, current) && current.ModTime().Equal(info.ModTime()) {
+		_ = os.Remove(path)
+	}
+}
+
+// writeFile writes the contents to a temporary file that is then renamed to path, so path never holds partial contents
+func writeFile(path string, contents []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.Write(contents); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
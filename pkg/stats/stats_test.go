@@ -0,0 +1,219 @@
+package stats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle"
+)
+
+func TestStatsFixture(t *testing.T) {
+	gunit.Run(new(StatsFixture), t)
+}
+
+type StatsFixture struct {
+	*gunit.Fixture
+	dir  string
+	path string
+}
+
+func (this *StatsFixture) Setup() {
+	var err error
+	this.dir, err = os.MkdirTemp("", "stats")
+	this.So(err, should.BeNil)
+	this.path = filepath.Join(this.dir, "wordle", "stats.json")
+}
+
+func (this *StatsFixture) Teardown() {
+	os.RemoveAll(this.dir)
+}
+
+// games returns the records of games won in the numbers of guesses, where 0 is a game that was lost
+func games(numGuesses ...int) []Record {
+	records := make([]Record, len(numGuesses))
+	for i, n := range numGuesses {
+		records[i] = Record{NumGuesses: n, Won: n > 0}
+		if n == 0 {
+			records[i].NumGuesses = wordle.MaxNumGuesses
+		}
+	}
+	return records
+}
+
+func (this *StatsFixture) TestSummary() {
+	stats := Stats{Games: games(3, 4, 0, 2, 3, 3, 0, 4, 4)}
+	this.So(stats.Played(), should.Equal, 9)
+	this.So(stats.WinRate(), should.AlmostEqual, 7.0/9)
+	this.So(stats.CurrentStreak(), should.Equal, 2)
+	this.So(stats.MaxStreak(), should.Equal, 3)
+	this.So(stats.Distribution(), should.Resemble, []int{0, 1, 3, 3, 0, 0})
+}
+
+func (this *StatsFixture) TestNoGames() {
+	stats := Stats{}
+	this.So(stats.WinRate(), should.Equal, 0)
+	this.So(stats.CurrentStreak(), should.Equal, 0)
+	this.So(stats.Distribution(), should.BeEmpty)
+}
+
+func (this *StatsFixture) TestDistributionFollowsTheGuessLimit() {
+	stats := Stats{Games: games(3, 2)}
+	for i := range stats.Games {
+		stats.Games[i].MaxNumGuesses = 4
+	}
+	this.So(stats.Distribution(), should.Resemble, []int{0, 1, 1, 0})
+	this.So(stats.String(), should.EndWith, "3 | ██████████████████████████████ 1\n4 | 0\n")
+}
+
+func (this *StatsFixture) TestLongGames() {
+	stats := Stats{Games: games(8)}
+	this.So(stats.Distribution(), should.Resemble, []int{0, 0, 0, 0, 0, 0, 0, 1})
+}
+
+func (this *StatsFixture) TestString() {
+	stats := Stats{Games: games(3, 0, 2, 3, 3, 2)}
+	this.So(stats.String(), should.Equal, `Played 6 · Win % 83 · Current streak 4 · Max streak 4
+
+Guess distribution
+1 | 0
+2 | ████████████████████ 2 ←
+3 | ██████████████████████████████ 3
+4 | 0
+5 | 0
+6 | 0
+`)
+}
+
+func (this *StatsFixture) TestHintsUsed() {
+	stats := Stats{Games: games(3, 4, 2)}
+	stats.Games[0].Hints = 2
+	stats.Games[2].Hints = 1
+	this.So(stats.HintsUsed(), should.Equal, 3)
+	this.So(stats.String(), should.StartWith, "Played 3 · Win % 100 · Current streak 3 · Max streak 3 · Hints used 3\n")
+}
+
+func (this *StatsFixture) TestNewRecord() {
+	game, err := wordle.NewGame("sheen", nil, wordle.Rules{WordLength: 5, MaxNumGuesses: 6, HardMode: true})
+	this.So(err, should.BeNil)
+	game.Submit("soare")
+	game.Submit("sheen")
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	puzzleNumber := 985
+	this.So(NewRecord(game, &puzzleNumber, now), should.Resemble, Record{
+		Time: now, PuzzleNumber: &puzzleNumber, Target: "sheen", NumGuesses: 2, Won: true, HardMode: true, MaxNumGuesses: 6,
+	})
+}
+
+func (this *StatsFixture) TestAddAndLoad() {
+	stats, err := Load(this.path)
+	this.So(err, should.BeNil)
+	this.So(stats.Played(), should.Equal, 0)
+
+	for _, record := range games(3, 0, 4) {
+		stats, err = Add(this.path, record)
+		this.So(err, should.BeNil)
+	}
+	this.So(stats.Played(), should.Equal, 3)
+	loaded, err := Load(this.path)
+	this.So(err, should.BeNil)
+	this.So(loaded, should.Resemble, stats)
+
+	entries, _ := os.ReadDir(filepath.Dir(this.path))
+	this.So(entries, should.HaveLength, 1) // no temporary or lock files are left behind
+}
+
+func (this *StatsFixture) TestPuzzleZeroIsDaily() {
+	puzzleNumber := 0
+	_, err := Add(this.path, Record{PuzzleNumber: &puzzleNumber, NumGuesses: 3, Won: true})
+	this.So(err, should.BeNil)
+	_, err = Add(this.path, Record{NumGuesses: 3, Won: true})
+	this.So(err, should.BeNil)
+	loaded, err := Load(this.path)
+	this.So(err, should.BeNil)
+	this.So(loaded.Games[0].PuzzleNumber, should.NotBeNil)
+	this.So(*loaded.Games[0].PuzzleNumber, should.Equal, 0)
+	this.So(loaded.Games[1].PuzzleNumber, should.BeNil)
+}
+
+func (this *StatsFixture) TestConcurrentGames() {
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = Add(this.path, Record{NumGuesses: 3, Won: true})
+		}()
+	}
+	wg.Wait()
+	this.So(errors.Join(errs...), should.BeNil)
+	stats, err := Load(this.path)
+	this.So(err, should.BeNil)
+	this.So(stats.Played(), should.Equal, 20)
+}
+
+func (this *StatsFixture) TestInvalidFileIsKept() {
+	this.So(os.MkdirAll(filepath.Dir(this.path), 0o755), should.BeNil)
+	this.So(os.WriteFile(this.path, []byte(`{"games": [`), 0o600), should.BeNil)
+	_, err := Add(this.path, Record{NumGuesses: 3, Won: true})
+	this.So(errors.Is(err, ErrInvalidStats), should.BeTrue)
+	contents, _ := os.ReadFile(this.path)
+	this.So(string(contents), should.Equal, `{"games": [`)
+}
+
+func (this *StatsFixture) TestStaleLock() {
+	this.So(os.MkdirAll(filepath.Dir(this.path), 0o755), should.BeNil)
+	lockPath := this.path + ".lock"
+	this.So(os.WriteFile(lockPath, nil, 0o600), should.BeNil)
+	old := time.Now().Add(-time.Minute)
+	this.So(os.Chtimes(lockPath, old, old), should.BeNil)
+	_, err := Add(this.path, Record{NumGuesses: 3, Won: true})
+	this.So(err, should.BeNil)
+}
+
+func (this *StatsFixture) TestUnlockLeavesAnotherGamesLock() {
+	unlock, err := lock(this.path)
+	this.So(err, should.BeNil)
+	lockPath := this.path + ".lock"
+	// Another game took the lock for a stale one and made its own
+	this.So(os.Remove(lockPath), should.BeNil)
+	this.So(os.WriteFile(lockPath, nil, 0o600), should.BeNil)
+	unlock()
+	_, err = os.Stat(lockPath)
+	this.So(err, should.BeNil)
+}
+
+func (this *StatsFixture) TestStaleLockIsOnlyRemovedIfUnchanged() {
+	this.So(os.MkdirAll(filepath.Dir(this.path), 0o755), should.BeNil)
+	lockPath := this.path + ".lock"
+	this.So(os.WriteFile(lockPath, nil, 0o600), should.BeNil)
+	old := time.Now().Add(-time.Minute)
+	this.So(os.Chtimes(lockPath, old, old), should.BeNil)
+	stale, err := os.Stat(lockPath)
+	this.So(err, should.BeNil)
+	// Another game removed the stale lock and made its own before this one got to it
+	now := time.Now()
+	this.So(os.Chtimes(lockPath, now, now), should.BeNil)
+	removeIfUnchanged(lockPath, stale)
+	_, err = os.Stat(lockPath)
+	this.So(err, should.BeNil)
+}
+
+func (this *StatsFixture) TestDefaultPath() {
+	original, isSet := os.LookupEnv("XDG_DATA_HOME")
+	this.So(os.Setenv("XDG_DATA_HOME", this.dir), should.BeNil)
+	defer func() {
+		if isSet {
+			os.Setenv("XDG_DATA_HOME", original)
+		} else {
+			os.Unsetenv("XDG_DATA_HOME")
+		}
+	}()
+	this.So(DefaultPath(), should.Equal, filepath.Join(this.dir, "wordle", "stats.json"))
+}
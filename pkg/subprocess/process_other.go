@@ -0,0 +1,13 @@
+//go:build !unix
+
+package subprocess
+
+import "os/exec"
+
+// startProcessGroup does nothing where there are no process groups
+func startProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process cmd started. What it started keeps running.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
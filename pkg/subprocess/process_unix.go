@@ -0,0 +1,18 @@
+//go:build unix
+
+package subprocess
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// startProcessGroup makes cmd start in a process group of its own
+func startProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the process group cmd was started in, which has the id of the process
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
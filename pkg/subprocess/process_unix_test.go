@@ -0,0 +1,34 @@
+//go:build unix
+
+package subprocess
+
+import (
+	"errors"
+	"time"
+
+	"github.com/smarty/assertions/should"
+)
+
+func (this *SubprocessFixture) TestTimeoutStopsWhatTheProcessStarted() {
+	// The shell waits for sleep, which has the shell's stdout, so killing only the shell would leave stdout open
+	solver := New([]string{"sh", "-c", "sleep 30; echo"}, WithTimeout(200*time.Millisecond))
+	defer solver.Close()
+	solver.Reset()
+	process := solver.process
+	guessed := make(chan string)
+	go func() { guessed <- solver.Guess(nil) }()
+	select {
+	case guess := <-guessed:
+		this.So(guess, should.Equal, "")
+		this.So(errors.Is(solver.Err(), ErrNoReply), should.BeTrue)
+	case <-time.After(5 * time.Second):
+		this.Error("Guess is still waiting for the process")
+	}
+	select {
+	case <-process.exited:
+	case <-time.After(5 * time.Second):
+		this.Error("sleep is still running")
+	}
+	solver.Reset()
+	this.So(solver.Err(), should.BeNil)
+}
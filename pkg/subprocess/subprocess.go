@@ -0,0 +1,338 @@
+// Package subprocess runs a solver written in any language as a child process that speaks line-delimited JSON over
+// stdin and stdout. See the README for the protocol.
+package subprocess
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/tliddle1/wordle"
+)
+
+var (
+	ErrSolverExited   = errors.New("solver process exited")
+	ErrMalformedReply = errors.New("malformed reply from solver process")
+	ErrSolverFailed   = errors.New("solver process reported an error")
+	ErrNoReply        = errors.New("solver process didn't reply in time")
+)
+
+const (
+	maxLineLength = 1 << 20     // the longest reply the solver can send
+	stopGrace     = time.Second // more than the time it takes to stop a process that didn't reply and fail the guess
+	// waitDelay is how long the output of a process that exited is read for before it is closed, in case something it
+	// started and that wasn't stopped with it still has it open
+	waitDelay = 100 * time.Millisecond
+)
+
+// Solver implements wordle.Solver by asking a child process for every guess. The process is started the first time it
+// is needed and restarted by Reset if it has exited, so a crash only costs the game it happened in. If the process
+// can't come up with a guess, Guess returns "" and Err returns why.
+type Solver struct {
+	command []string
+	rules   wordle.Rules
+	timeout time.Duration
+	stderr  io.Writer
+
+	mu      sync.Mutex
+	process *process // nil until the process is started and after it is stopped
+	err     error
+}
+
+// process is a started solver process. A process that is stopped is left to exit in the background, so what is known
+// about it is kept apart from the Solver, which can start another one in the meantime.
+type process struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	lines   chan string   // the lines the process writes, closed after it exits
+	exited  chan struct{} // closed when the process exits
+	exitErr error         // why the process exited, set before exited is closed
+	readErr error         // why the output of the process couldn't be read, set before exited is closed
+}
+
+// Option configures a Solver
+type Option func(*Solver)
+
+// WithRules tells the process the rules the games are played by instead of wordle.DefaultRules
+func WithRules(rules wordle.Rules) Option {
+	return func(solver *Solver) {
+		solver.rules = rules
+	}
+}
+
+// WithTimeout stops the process and fails the guess with an error wrapping ErrNoReply if it takes longer than timeout
+// to reply. Only the game is lost, the process is started again for the next one. The solver can take a little longer
+// than timeout to give up on a guess, so an evaluator that has a timeout of its own should get EvaluatorTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(solver *Solver) {
+		solver.timeout = timeout
+	}
+}
+
+// WithStderr sends what the process writes to stderr to w instead of os.Stderr
+func WithStderr(w io.Writer) Option {
+	return func(solver *Solver) {
+		solver.stderr = w
+	}
+}
+
+// New returns a Solver that runs command, the program followed by its arguments
+func New(command []string, options ...Option) *Solver {
+	solver := Solver{
+		command: command,
+		rules:   wordle.DefaultRules,
+		stderr:  os.Stderr,
+	}
+	for _, option := range options {
+		option(&solver)
+	}
+	return &solver
+}
+
+// EvaluatorTimeout returns the guess timeout for an evaluator of solvers with the timeout, which leaves them the time to
+// stop their process and fail the guess themselves, so that only the game is lost and not the whole evaluation. It is
+// 0, no timeout, if timeout is.
+func EvaluatorTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+	return timeout + stopGrace
+}
+
+// NewFactory returns a wordle.SolverFactory whose solvers each run their own copy of command
+func NewFactory(command []string, options ...Option) wordle.SolverFactory {
+	return func() wordle.Solver {
+		return New(command, options...)
+	}
+}
+
+func (this *Solver) Debug() bool {
+	return false
+}
+
+// Guess sends the turn history to the process and returns the guess it replies with, or "" if it doesn't
+func (this *Solver) Guess(turnHistory []wordle.Turn) string {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.err != nil {
+		return ""
+	}
+	if this.process == nil {
+		if err := this.restart(); err != nil {
+			this.fail(err)
+			return ""
+		}
+	}
+	if turnHistory == nil {
+		turnHistory = []wordle.Turn{}
+	}
+	if err := this.send(guessRequest{Type: "guess", Turns: turnHistory}); err != nil {
+		this.fail(err)
+		return ""
+	}
+	reply, err := this.receive()
+	if err != nil {
+		this.fail(err)
+		return ""
+	}
+	return *reply.Guess
+}
+
+// Reset starts a new game, restarting the process if it has exited
+func (this *Solver) Reset() {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.err = nil
+	if this.hasExited() {
+		this.stop()
+	}
+	var err error
+	if this.process == nil {
+		err = this.restart()
+	} else {
+		err = this.send(resetRequest{Type: "reset", Rules: this.rules})
+	}
+	if err != nil {
+		this.fail(err)
+	}
+}
+
+// Err returns why the last guess failed, or nil if it didn't. It implements wordle.ErrorReporter.
+func (this *Solver) Err() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	return this.err
+}
+
+// Close stops the process
+func (this *Solver) Close() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.stop()
+	return nil
+}
+
+// private
+
+type resetRequest struct {
+	Type  string       `json:"type"`
+	Rules wordle.Rules `json:"rules"`
+}
+
+type guessRequest struct {
+	Type  string        `json:"type"`
+	Turns []wordle.Turn `json:"turns"`
+}
+
+type reply struct {
+	Guess *string `json:"guess"`
+	Error string  `json:"error"`
+}
+
+// restart starts the process and tells it a game is starting
+func (this *Solver) restart() error {
+	if err := this.start(); err != nil {
+		return err
+	}
+	return this.send(resetRequest{Type: "reset", Rules: this.rules})
+}
+
+func (this *Solver) start() error {
+	if len(this.command) == 0 {
+		return errors.New("no solver command")
+	}
+	cmd := exec.Command(this.command[0], this.command[1:]...)
+	cmd.Stderr = this.stderr
+	cmd.WaitDelay = waitDelay
+	// The process gets a process group of its own, so that stopping it also stops whatever it started, like the
+	// solver run by a wrapper script
+	startProcessGroup(cmd)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+
+	process := &process{cmd: cmd, stdin: stdin, lines: make(chan string), exited: make(chan struct{})}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(nil, maxLineLength)
+		for scanner.Scan() {
+			process.lines <- scanner.Text()
+		}
+		if err := scanner.Err(); err != nil {
+			// Like a line that was too long. The process is out of step with the protocol, and might be waiting on
+			// stdin, so it's killed rather than waited on.
+			process.readErr = fmt.Errorf("%w: %w", ErrMalformedReply, err)
+			killProcessGroup(cmd)
+		}
+		process.exitErr = cmd.Wait()
+		close(process.exited)
+		close(process.lines)
+	}()
+	this.process = process
+	return nil
+}
+
+// hasExited returns true if the process was started and has exited since
+func (this *Solver) hasExited() bool {
+	if this.process == nil {
+		return false
+	}
+	select {
+	case <-this.process.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop kills the process and everything it started. It doesn't wait for them to exit: what is left of their output is
+// read and thrown away in the background.
+func (this *Solver) stop() {
+	if this.process == nil {
+		return
+	}
+	this.process.stdin.Close()
+	killProcessGroup(this.process.cmd)
+	go func(lines chan string) {
+		for range lines {
+		}
+	}(this.process.lines)
+	this.process = nil
+}
+
+// fail records the error and stops the process, which can't be trusted to be in step with the protocol anymore
+func (this *Solver) fail(err error) {
+	this.err = err
+	this.stop()
+}
+
+func (this *Solver) send(request any) error {
+	if this.process == nil {
+		return ErrSolverExited
+	}
+	message, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	if _, err = this.process.stdin.Write(append(message, '\n')); err != nil {
+		return fmt.Errorf("%w: %w", ErrSolverExited, err)
+	}
+	return nil
+}
+
+func (this *Solver) receive() (reply, error) {
+	var timeout <-chan time.Time
+	if this.timeout > 0 {
+		timer := time.NewTimer(this.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case line, ok := <-this.process.lines:
+		if !ok {
+			return reply{}, this.exitError()
+		}
+		return parseReply(line)
+	case <-timeout:
+		return reply{}, fmt.Errorf("%w: no reply after %s", ErrNoReply, this.timeout)
+	}
+}
+
+// exitError returns the error for a process that exited, or was killed, while a reply was expected
+func (this *Solver) exitError() error {
+	if this.process.readErr != nil {
+		return this.process.readErr
+	}
+	if this.process.exitErr != nil {
+		return fmt.Errorf("%w: %w", ErrSolverExited, this.process.exitErr)
+	}
+	return ErrSolverExited
+}
+
+func parseReply(line string) (reply, error) {
+	var message reply
+	if err := json.Unmarshal([]byte(line), &message); err != nil {
+		return reply{}, fmt.Errorf("%w: %q: %w", ErrMalformedReply, line, err)
+	}
+	if message.Error != "" {
+		return reply{}, fmt.Errorf("%w: %s", ErrSolverFailed, message.Error)
+	}
+	if message.Guess == nil {
+		return reply{}, fmt.Errorf("%w: %q has no guess", ErrMalformedReply, line)
+	}
+	return message, nil
+}
@@ -0,0 +1,208 @@
+package subprocess
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle"
+)
+
+const helperArg = "subprocess-test-helper"
+
+// TestMain lets the test binary run itself as a solver process
+func TestMain(m *testing.M) {
+	if len(os.Args) >= 3 && os.Args[1] == helperArg {
+		runHelper(os.Args[2], os.Args[3:])
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// helper returns the command that runs the helper solver with the mode
+func helper(mode string, args ...string) []string {
+	return append([]string{os.Args[0], helperArg, mode}, args...)
+}
+
+// runHelper is a solver process that guesses "crane", "salet" and "crank" in that order, unless its mode makes it
+// misbehave when asked for a guess
+func runHelper(mode string, args []string) {
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+	reset := false
+	for scanner.Scan() {
+		var request struct {
+			Type  string
+			Rules wordle.Rules
+			Turns []wordle.Turn
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			encoder.Encode(map[string]string{"error": err.Error()})
+			continue
+		}
+		if request.Type == "reset" {
+			reset = request.Rules == wordle.DefaultRules
+			continue
+		}
+		switch mode {
+		case "crash":
+			os.Exit(3)
+		case "crash-once":
+			if _, err := os.Stat(args[0]); err != nil {
+				os.WriteFile(args[0], nil, 0o600)
+				os.Exit(3)
+			}
+		case "hang":
+			time.Sleep(time.Minute)
+		case "hang-once":
+			if _, err := os.Stat(args[0]); err != nil {
+				os.WriteFile(args[0], nil, 0o600)
+				time.Sleep(time.Minute)
+			}
+		case "long":
+			fmt.Println(strings.Repeat("x", 2*maxLineLength))
+			continue
+		case "garbage":
+			fmt.Println("not json")
+			continue
+		case "error":
+			encoder.Encode(map[string]string{"error": "out of ideas"})
+			continue
+		}
+		if !reset {
+			encoder.Encode(map[string]string{"error": "guess before reset"})
+			continue
+		}
+		encoder.Encode(map[string]string{"guess": []string{"crane", "salet", "crank"}[len(request.Turns)]})
+	}
+}
+
+func TestSubprocessFixture(t *testing.T) {
+	gunit.Run(new(SubprocessFixture), t)
+}
+
+type SubprocessFixture struct {
+	*gunit.Fixture
+}
+
+func (this *SubprocessFixture) newEvaluator(targets ...string) *wordle.Evaluator {
+	return wordle.NewEvaluator(
+		wordle.WithTargets(targets),
+		wordle.WithGuesses([]string{"crane", "salet", "crank"}),
+		wordle.WithoutShuffle(),
+		wordle.WithOutput(io.Discard),
+	)
+}
+
+func (this *SubprocessFixture) evaluate(command []string, targets ...string) *wordle.EvaluationReport {
+	report, err := this.newEvaluator(targets...).EvaluateSolverParallel(NewFactory(command, WithStderr(io.Discard)), 2)
+	this.So(err, should.BeNil)
+	return report
+}
+
+func (this *SubprocessFixture) TestPlaysGames() {
+	report := this.evaluate(helper("play"), "salet", "crank")
+	this.So(report.WinRate, should.Equal, 1)
+	this.So(report.Games[0].NumGuesses, should.Equal, 2)
+	this.So(report.Games[1].NumGuesses, should.Equal, 3)
+}
+
+func (this *SubprocessFixture) TestGuessWithoutReset() {
+	solver := New(helper("play"))
+	defer solver.Close()
+	this.So(solver.Guess(nil), should.Equal, "crane")
+	this.So(solver.Err(), should.BeNil)
+}
+
+func (this *SubprocessFixture) TestCrash() {
+	solver := New(helper("crash"), WithStderr(io.Discard))
+	defer solver.Close()
+	solver.Reset()
+	this.So(solver.Guess(nil), should.Equal, "")
+	this.So(errors.Is(solver.Err(), ErrSolverExited), should.BeTrue)
+	this.So(solver.Err().Error(), should.ContainSubstring, "exit status 3")
+	this.So(solver.Guess(nil), should.Equal, "")
+}
+
+func (this *SubprocessFixture) TestRestartsAfterCrash() {
+	dir, err := os.MkdirTemp("", "subprocess")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	// One solver plays the games in order, so the first one is the one the process crashes in
+	solver := New(helper("crash-once", filepath.Join(dir, "crashed")), WithStderr(io.Discard))
+	defer solver.Close()
+	report, err := this.newEvaluator("salet", "crank").EvaluateSolver(solver)
+	this.So(err, should.BeNil)
+	this.So(errors.Is(report.Games[0].Err, ErrSolverExited), should.BeTrue)
+	this.So(report.Games[1].Won(), should.BeTrue)
+}
+
+func (this *SubprocessFixture) TestTimeout() {
+	solver := New(helper("hang"), WithTimeout(50*time.Millisecond))
+	defer solver.Close()
+	solver.Reset()
+	this.So(solver.Guess(nil), should.Equal, "")
+	this.So(errors.Is(solver.Err(), ErrNoReply), should.BeTrue)
+	this.So(errors.Is(solver.Err(), wordle.ErrGuessTimeout), should.BeFalse)
+}
+
+func (this *SubprocessFixture) TestTimeoutOnlyLosesTheGame() {
+	dir, err := os.MkdirTemp("", "subprocess")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	solver := New(helper("hang-once", filepath.Join(dir, "hung")), WithTimeout(50*time.Millisecond))
+	defer solver.Close()
+	report, err := this.newEvaluator("salet", "crank").EvaluateSolver(solver)
+	this.So(err, should.BeNil)
+	this.So(errors.Is(report.Games[0].Err, ErrNoReply), should.BeTrue)
+	this.So(report.Games[1].Won(), should.BeTrue)
+}
+
+func (this *SubprocessFixture) TestReplyTooLong() {
+	solver := New(helper("long"))
+	defer solver.Close()
+	solver.Reset()
+	this.So(solver.Guess(nil), should.Equal, "")
+	this.So(errors.Is(solver.Err(), ErrMalformedReply), should.BeTrue)
+	this.So(errors.Is(solver.Err(), bufio.ErrTooLong), should.BeTrue)
+	solver.Reset()
+	this.So(solver.Err(), should.BeNil)
+}
+
+func (this *SubprocessFixture) TestMalformedReply() {
+	solver := New(helper("garbage"))
+	defer solver.Close()
+	solver.Reset()
+	this.So(solver.Guess(nil), should.Equal, "")
+	this.So(errors.Is(solver.Err(), ErrMalformedReply), should.BeTrue)
+}
+
+func (this *SubprocessFixture) TestReportedError() {
+	solver := New(helper("error"))
+	defer solver.Close()
+	solver.Reset()
+	this.So(solver.Guess(nil), should.Equal, "")
+	this.So(errors.Is(solver.Err(), ErrSolverFailed), should.BeTrue)
+	this.So(solver.Err().Error(), should.ContainSubstring, "out of ideas")
+}
+
+func (this *SubprocessFixture) TestMissingProgram() {
+	solver := New([]string{filepath.Join(os.TempDir(), "no-such-solver")})
+	solver.Reset()
+	this.So(solver.Err(), should.NotBeNil)
+	this.So(solver.Guess(nil), should.Equal, "")
+}
+
+func (this *SubprocessFixture) TestEvaluatorReportsSolverErrors() {
+	report := this.evaluate(helper("error"), "salet")
+	this.So(errors.Is(report.Games[0].Err, ErrSolverFailed), should.BeTrue)
+}
@@ -0,0 +1,261 @@
+package wordle
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+)
+
+// Renderer draws turns and keyboards
+type Renderer interface {
+	// RenderTurn writes the guess of the turn colored by its pattern, followed by a newline
+	RenderTurn(w io.Writer, turn Turn) error
+	// RenderKeyboard writes the QWERTY keyboard with the color of every guessed letter, ending with a newline
+	RenderKeyboard(w io.Writer, keyboard Keyboard) error
+}
+
+// RGB is a 24-bit color
+type RGB struct {
+	R, G, B uint8
+}
+
+// Hex returns the color the way CSS writes it, like "#538d4e"
+func (this RGB) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", this.R, this.G, this.B)
+}
+
+// TileColors are the colors tiles are filled with
+type TileColors struct {
+	Gray   RGB
+	Yellow RGB
+	Green  RGB
+}
+
+var (
+	StandardColors     = TileColors{Gray: RGB{58, 58, 60}, Yellow: RGB{181, 159, 59}, Green: RGB{83, 141, 78}}
+	HighContrastColors = TileColors{Gray: RGB{58, 58, 60}, Yellow: RGB{133, 192, 249}, Green: RGB{245, 121, 58}}
+)
+
+func (this TileColors) fill(color LetterColor) RGB {
+	switch color {
+	case Green:
+		return this.Green
+	case Yellow:
+		return this.Yellow
+	default:
+		return this.Gray
+	}
+}
+
+var (
+	// ANSIRenderer draws letters as white tiles on the colors of the app, with truecolor ANSI escape codes
+	ANSIRenderer Renderer = TileRenderer{Colors: StandardColors}
+	// HighContrastRenderer is ANSIRenderer with orange for green and blue for yellow, like the app's high contrast mode
+	HighContrastRenderer Renderer = TileRenderer{Colors: HighContrastColors}
+	// PlainRenderer draws without color: green letters like [E], yellow letters like (E) and gray letters between spaces
+	PlainRenderer Renderer = plainRenderer{}
+	// EmojiRenderer draws the squares of a share grid next to the guess
+	EmojiRenderer Renderer = EmojiThemeRenderer{Theme: DarkTheme}
+	// HTMLRenderer draws a <div> of tiles with inline styles, and classes to style them with instead
+	HTMLRenderer Renderer = HTMLTileRenderer{Colors: StandardColors}
+	// JSONRenderer writes every turn as a JSON object on its own line, like {"guess":"crane","pattern":"..YG."}
+	JSONRenderer Renderer = jsonRenderer{}
+)
+
+// Renderers are the renderers by the names the commands take them by
+var Renderers = map[string]Renderer{
+	"ansi":          ANSIRenderer,
+	"high-contrast": HighContrastRenderer,
+	"plain":         PlainRenderer,
+	"emoji":         EmojiRenderer,
+	"html":          HTMLRenderer,
+	"json":          JSONRenderer,
+}
+
+// DefaultRenderer returns PlainRenderer if the NO_COLOR environment variable is set to anything (see no-color.org),
+// and ANSIRenderer otherwise
+func DefaultRenderer() Renderer {
+	if os.Getenv("NO_COLOR") != "" {
+		return PlainRenderer
+	}
+	return ANSIRenderer
+}
+
+// RendererNamed returns the renderer in Renderers with the name, or DefaultRenderer() if the name is empty. The error
+// wraps ErrUnknownRenderer if there is no renderer with the name.
+func RendererNamed(name string) (Renderer, error) {
+	if name == "" {
+		return DefaultRenderer(), nil
+	}
+	renderer, ok := Renderers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRenderer, name)
+	}
+	return renderer, nil
+}
+
+// TileRenderer draws letters as tiles with truecolor ANSI escape codes. Letters that weren't guessed are left as they
+// are.
+type TileRenderer struct {
+	Colors TileColors
+}
+
+func (this TileRenderer) tile(letter rune, color LetterColor) string {
+	if color == 0 {
+		return " " + upper(letter) + " "
+	}
+	fill := this.Colors.fill(color)
+	return fmt.Sprintf("\033[1;38;2;255;255;255;48;2;%d;%d;%dm %s \033[0m", fill.R, fill.G, fill.B, upper(letter))
+}
+
+func (this TileRenderer) RenderTurn(w io.Writer, turn Turn) error {
+	var builder strings.Builder
+	colors := turn.Pattern.Colors()
+	for i, letter := range []rune(turn.Guess) {
+		if i < len(colors) {
+			builder.WriteString(this.tile(letter, colors[i]))
+		}
+	}
+	builder.WriteByte('\n')
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (this TileRenderer) RenderKeyboard(w io.Writer, keyboard Keyboard) error {
+	var builder strings.Builder
+	for i, row := range keyboard.Rows(QWERTY) {
+		builder.WriteString(strings.Repeat(" ", i))
+		for _, letter := range row {
+			builder.WriteString(this.tile(letter, keyboard[letter]))
+		}
+		builder.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+type plainRenderer struct{}
+
+func (plainRenderer) RenderTurn(w io.Writer, turn Turn) error {
+	var builder strings.Builder
+	colors := turn.Pattern.Colors()
+	for i, letter := range []rune(turn.Guess) {
+		if i >= len(colors) {
+			break
+		}
+		switch colors[i] {
+		case Green:
+			builder.WriteString("[" + upper(letter) + "]")
+		case Yellow:
+			builder.WriteString("(" + upper(letter) + ")")
+		default:
+			builder.WriteString(" " + upper(letter) + " ")
+		}
+	}
+	builder.WriteByte('\n')
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (plainRenderer) RenderKeyboard(w io.Writer, keyboard Keyboard) error {
+	_, err := io.WriteString(w, keyboard.String())
+	return err
+}
+
+// EmojiThemeRenderer draws the squares of the theme followed by the guess, like "⬛⬛🟨🟩⬛ CRANE". Keys are the square
+// of their color followed by the letter.
+type EmojiThemeRenderer struct {
+	Theme ShareTheme
+}
+
+func (this EmojiThemeRenderer) RenderTurn(w io.Writer, turn Turn) error {
+	var builder strings.Builder
+	for _, color := range turn.Pattern.Colors() {
+		builder.WriteString(this.Theme.square(color))
+	}
+	_, err := fmt.Fprintf(w, "%s %s\n", builder.String(), strings.ToUpper(turn.Guess))
+	return err
+}
+
+func (this EmojiThemeRenderer) RenderKeyboard(w io.Writer, keyboard Keyboard) error {
+	var builder strings.Builder
+	for _, row := range keyboard.Rows(QWERTY) {
+		keys := make([]string, len(row))
+		for i, letter := range row {
+			keys[i] = string(letter)
+			if color := keyboard[letter]; color != 0 {
+				keys[i] = this.Theme.square(color) + upper(letter)
+			}
+		}
+		builder.WriteString(strings.Join(keys, " ") + "\n")
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// HTMLTileRenderer draws a <div class="wordle-row"> of <span class="wordle-tile wordle-green"> tiles for a turn, and a
+// <div class="wordle-keyboard"> of rows of <kbd class="wordle-key"> keys for a keyboard. Tiles and guessed keys are
+// filled with the colors by inline styles.
+type HTMLTileRenderer struct {
+	Colors TileColors
+}
+
+// tile returns the letter in an element of the class, with the color's class and fill if it has one
+func (this HTMLTileRenderer) tile(element, class string, letter rune, color LetterColor) string {
+	text := html.EscapeString(upper(letter))
+	if color == 0 {
+		return fmt.Sprintf(`<%s class="%s">%s</%s>`, element, class, text, element)
+	}
+	return fmt.Sprintf(`<%s class="%s wordle-%s" style="background-color:%s;color:#ffffff">%s</%s>`,
+		element, class, colorNames[color], this.Colors.fill(color).Hex(), text, element)
+}
+
+func (this HTMLTileRenderer) RenderTurn(w io.Writer, turn Turn) error {
+	var builder strings.Builder
+	builder.WriteString(`<div class="wordle-row">`)
+	colors := turn.Pattern.Colors()
+	for i, letter := range []rune(turn.Guess) {
+		if i < len(colors) {
+			builder.WriteString(this.tile("span", "wordle-tile", letter, colors[i]))
+		}
+	}
+	builder.WriteString("</div>\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (this HTMLTileRenderer) RenderKeyboard(w io.Writer, keyboard Keyboard) error {
+	var builder strings.Builder
+	builder.WriteString(`<div class="wordle-keyboard">` + "\n")
+	for _, row := range keyboard.Rows(QWERTY) {
+		builder.WriteString(`<div class="wordle-keys">`)
+		for _, letter := range row {
+			builder.WriteString(this.tile("kbd", "wordle-key", letter, keyboard[letter]))
+		}
+		builder.WriteString("</div>\n")
+	}
+	builder.WriteString("</div>\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// colorNames are the names colors have in HTML classes and JSON
+var colorNames = map[LetterColor]string{Gray: "gray", Yellow: "yellow", Green: "green"}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) RenderTurn(w io.Writer, turn Turn) error {
+	return json.NewEncoder(w).Encode(turn)
+}
+
+// RenderKeyboard writes the guessed letters with their color names, like {"keyboard":{"a":"green","i":"gray"}}
+func (jsonRenderer) RenderKeyboard(w io.Writer, keyboard Keyboard) error {
+	letters := make(map[string]string, len(keyboard))
+	for letter, color := range keyboard {
+		letters[string(letter)] = colorNames[color]
+	}
+	return json.NewEncoder(w).Encode(map[string]any{"keyboard": letters})
+}
@@ -0,0 +1,140 @@
+package wordle
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestRenderFixture(t *testing.T) {
+	gunit.Run(new(RenderFixture), t)
+}
+
+type RenderFixture struct {
+	*gunit.Fixture
+	turn     Turn
+	keyboard Keyboard
+}
+
+func (this *RenderFixture) Setup() {
+	turns := turnsFor("snake", "slain")
+	this.turn = turns[0] // G.G.Y
+	this.keyboard = NewKeyboard(turns)
+}
+
+func (this *RenderFixture) renderTurn(renderer Renderer, turn Turn) string {
+	var output strings.Builder
+	this.So(renderer.RenderTurn(&output, turn), should.BeNil)
+	return output.String()
+}
+
+func (this *RenderFixture) renderKeyboard(renderer Renderer) []string {
+	var output strings.Builder
+	this.So(renderer.RenderKeyboard(&output, this.keyboard), should.BeNil)
+	return strings.Split(output.String(), "\n")
+}
+
+func (this *RenderFixture) TestANSI() {
+	this.So(this.renderTurn(ANSIRenderer, this.turn), should.Equal,
+		"\033[1;38;2;255;255;255;48;2;83;141;78m S \033[0m"+
+			"\033[1;38;2;255;255;255;48;2;58;58;60m L \033[0m"+
+			"\033[1;38;2;255;255;255;48;2;83;141;78m A \033[0m"+
+			"\033[1;38;2;255;255;255;48;2;58;58;60m I \033[0m"+
+			"\033[1;38;2;255;255;255;48;2;181;159;59m N \033[0m\n")
+	lines := this.renderKeyboard(ANSIRenderer)
+	this.So(lines, should.HaveLength, 4)
+	this.So(lines[0], should.StartWith, " Q  W  E ")
+	this.So(lines[1], should.StartWith, " \033[1;38;2;255;255;255;48;2;83;141;78m A \033[0m")
+}
+
+func (this *RenderFixture) TestHighContrast() {
+	output := this.renderTurn(HighContrastRenderer, this.turn)
+	this.So(output, should.StartWith, "\033[1;38;2;255;255;255;48;2;245;121;58m S \033[0m")
+	this.So(output, should.EndWith, "\033[1;38;2;255;255;255;48;2;133;192;249m N \033[0m\n")
+}
+
+func (this *RenderFixture) TestPlain() {
+	this.So(this.renderTurn(PlainRenderer, this.turn), should.Equal, "[S] L [A] I (N)\n")
+	this.So(this.renderTurn(PlainRenderer, turnsFor("ñandú", "ñoñas")[0]), should.Equal, "[Ñ] O  Ñ (A) S \n")
+	this.So(strings.Join(this.renderKeyboard(PlainRenderer), "\n"), should.Equal, this.keyboard.String())
+}
+
+func (this *RenderFixture) TestEmoji() {
+	this.So(this.renderTurn(EmojiRenderer, this.turn), should.Equal, "🟩⬛🟩⬛🟨 SLAIN\n")
+	this.So(this.renderTurn(EmojiThemeRenderer{Theme: HighContrastLightTheme}, this.turn), should.Equal, "🟧⬜🟧⬜🟦 SLAIN\n")
+	lines := this.renderKeyboard(EmojiRenderer)
+	this.So(lines[0], should.Equal, "q w e r t y u ⬛I o p")
+	this.So(lines[1], should.Equal, "🟩A 🟩S d f g h j k ⬛L")
+	this.So(lines[2], should.Equal, "z x c v b 🟨N m")
+}
+
+func (this *RenderFixture) TestHTML() {
+	this.So(this.renderTurn(HTMLRenderer, Turn{"a<b", Pattern{Green, Gray, Yellow}}), should.Equal, `<div class="wordle-row">`+
+		`<span class="wordle-tile wordle-green" style="background-color:#538d4e;color:#ffffff">A</span>`+
+		`<span class="wordle-tile wordle-gray" style="background-color:#3a3a3c;color:#ffffff">&lt;</span>`+
+		`<span class="wordle-tile wordle-yellow" style="background-color:#b59f3b;color:#ffffff">B</span>`+
+		"</div>\n")
+	lines := this.renderKeyboard(HTMLRenderer)
+	this.So(lines[0], should.Equal, `<div class="wordle-keyboard">`)
+	this.So(lines[1], should.StartWith, `<div class="wordle-keys"><kbd class="wordle-key">Q</kbd>`)
+	this.So(lines[3], should.ContainSubstring, `<kbd class="wordle-key wordle-yellow" style="background-color:#b59f3b;color:#ffffff">N</kbd>`)
+	this.So(lines[4], should.Equal, "</div>")
+}
+
+func (this *RenderFixture) TestJSON() {
+	this.So(this.renderTurn(JSONRenderer, this.turn), should.Equal, `{"guess":"slain","pattern":"G.G.Y"}`+"\n")
+	this.So(this.renderKeyboard(JSONRenderer)[0], should.Equal,
+		`{"keyboard":{"a":"green","i":"gray","l":"gray","n":"yellow","s":"green"}}`)
+}
+
+func (this *RenderFixture) TestRenderers() {
+	for _, name := range []string{"ansi", "high-contrast", "plain", "emoji", "html", "json"} {
+		this.So(Renderers, should.ContainKey, name)
+	}
+}
+
+func (this *RenderFixture) TestRendererNamed() {
+	renderer, err := RendererNamed("emoji")
+	this.So(err, should.BeNil)
+	this.So(renderer, should.Equal, EmojiRenderer)
+	renderer, err = RendererNamed("")
+	this.So(err, should.BeNil)
+	this.So(renderer, should.Equal, DefaultRenderer())
+	_, err = RendererNamed("sepia")
+	this.So(err, should.Wrap, ErrUnknownRenderer)
+}
+
+func (this *RenderFixture) TestDefaultRenderer() {
+	original, isSet := os.LookupEnv("NO_COLOR")
+	defer func() {
+		if isSet {
+			os.Setenv("NO_COLOR", original)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+	this.So(os.Setenv("NO_COLOR", "1"), should.BeNil)
+	this.So(DefaultRenderer(), should.Equal, PlainRenderer)
+	this.So(os.Unsetenv("NO_COLOR"), should.BeNil)
+	this.So(DefaultRenderer(), should.Equal, ANSIRenderer)
+}
+
+func (this *RenderFixture) TestEvaluatorDebugOutput() {
+	var output strings.Builder
+	evaluator := NewEvaluator(WithOutput(&output), WithRenderer(PlainRenderer))
+	_, err := evaluator.PlayGame("snake", debugSolver{NewDummySolverFixedGuesses("slain", "snake")})
+	this.So(err, should.BeNil)
+	this.So(output.String(), should.Equal, "[S] L [A] I (N)\n[S][N][A][K][E]\n2 guesses\n")
+}
+
+// debugSolver is a solver in debug mode
+type debugSolver struct {
+	Solver
+}
+
+func (this debugSolver) Debug() bool {
+	return true
+}
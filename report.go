@@ -0,0 +1,84 @@
+package wordle
+
+import (
+	"cmp"
+	"slices"
+)
+
+const numWorstTargets = 10
+
+// GameResult is how a solver did on a single wordle
+type GameResult struct {
+	Target     string
+	NumGuesses int    // the number of guesses made, including the winning guess
+	Turns      []Turn // every guess made with its pattern, including the winning guess
+	Err        error  // why the game was not won, nil if it was
+}
+
+// Won returns true if the solver found the target
+func (this GameResult) Won() bool {
+	return this.Err == nil
+}
+
+// EvaluationReport summarizes how a solver did on a set of wordles. The guess statistics only count the games that were
+// won; the games that weren't are listed in Failures.
+type EvaluationReport struct {
+	Games        []GameResult // every game in the order the targets were played
+	Histogram    []int        // Histogram[n] is the number of games won in n guesses
+	Failures     []GameResult // the games that were not won
+	WinRate      float64      // the fraction of games that were won
+	Mean         float64      // the mean number of guesses per game won
+	Median       float64      // the median number of guesses per game won
+	Max          int          // the most guesses needed to win a game
+	WorstTargets []GameResult // the lost games followed by the games that took the most guesses
+}
+
+func newEvaluationReport(games []GameResult) *EvaluationReport {
+	report := EvaluationReport{Games: games}
+	var numGuesses []int
+	for _, game := range games {
+		if !game.Won() {
+			report.Failures = append(report.Failures, game)
+			continue
+		}
+		numGuesses = append(numGuesses, game.NumGuesses)
+		for len(report.Histogram) <= game.NumGuesses {
+			report.Histogram = append(report.Histogram, 0)
+		}
+		report.Histogram[game.NumGuesses]++
+	}
+	if len(games) > 0 {
+		report.WinRate = float64(len(numGuesses)) / float64(len(games))
+	}
+	if len(numGuesses) > 0 {
+		slices.Sort(numGuesses)
+		total := 0
+		for _, n := range numGuesses {
+			total += n
+		}
+		report.Mean = float64(total) / float64(len(numGuesses))
+		middle := len(numGuesses) / 2
+		if len(numGuesses)%2 == 0 {
+			report.Median = float64(numGuesses[middle-1]+numGuesses[middle]) / 2
+		} else {
+			report.Median = float64(numGuesses[middle])
+		}
+		report.Max = numGuesses[len(numGuesses)-1]
+	}
+	report.WorstTargets = worstTargets(games)
+	return &report
+}
+
+func worstTargets(games []GameResult) []GameResult {
+	worst := slices.Clone(games)
+	slices.SortStableFunc(worst, func(a, b GameResult) int {
+		if a.Won() != b.Won() {
+			if a.Won() {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(b.NumGuesses, a.NumGuesses)
+	})
+	return worst[:min(len(worst), numWorstTargets)]
+}
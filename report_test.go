@@ -0,0 +1,51 @@
+package wordle
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestReportFixture(t *testing.T) {
+	gunit.Run(new(ReportFixture), t)
+}
+
+type ReportFixture struct {
+	*gunit.Fixture
+}
+
+func (this *ReportFixture) TestEmpty() {
+	report := newEvaluationReport(nil)
+	this.So(report.WinRate, should.Equal, 0)
+	this.So(report.Mean, should.Equal, 0)
+	this.So(report.WorstTargets, should.BeEmpty)
+}
+
+func (this *ReportFixture) TestStatistics() {
+	lost := GameResult{Target: "lapse", NumGuesses: 6, Err: ErrLostGame}
+	report := newEvaluationReport([]GameResult{
+		{Target: "angry", NumGuesses: 3},
+		{Target: "crane", NumGuesses: 2},
+		lost,
+		{Target: "jazzy", NumGuesses: 5},
+		{Target: "tiger", NumGuesses: 3},
+	})
+	this.So(report.Histogram, should.Resemble, []int{0, 0, 1, 2, 0, 1})
+	this.So(report.Failures, should.Resemble, []GameResult{lost})
+	this.So(report.WinRate, should.Equal, 0.8)
+	this.So(report.Mean, should.Equal, 3.25)
+	this.So(report.Median, should.Equal, 3)
+	this.So(report.Max, should.Equal, 5)
+	this.So(report.WorstTargets[0].Target, should.Equal, "lapse")
+	this.So(report.WorstTargets[1].Target, should.Equal, "jazzy")
+	this.So(report.WorstTargets[2].Target, should.Equal, "angry")
+}
+
+func (this *ReportFixture) TestWorstTargetsLimit() {
+	var games []GameResult
+	for range numWorstTargets + 5 {
+		games = append(games, GameResult{NumGuesses: 4})
+	}
+	this.So(newEvaluationReport(games).WorstTargets, should.HaveLength, numWorstTargets)
+}
@@ -0,0 +1,40 @@
+package wordle
+
+import "fmt"
+
+// Rules are the parameters of a game of wordle
+type Rules struct {
+	WordLength    int  `json:"wordLength"`    // the number of letters in every target and guess
+	MaxNumGuesses int  `json:"maxNumGuesses"` // the number of guesses allowed before the game is lost
+	HardMode      bool `json:"hardMode"`      // whether every guess has to use the hints from earlier guesses (see CheckHardMode)
+}
+
+// DefaultRules are the rules of the original game
+var DefaultRules = Rules{WordLength: WordLength, MaxNumGuesses: MaxNumGuesses}
+
+// Validate returns an error wrapping ErrInvalidRules if a game can't be played with these rules
+func (this Rules) Validate() error {
+	if this.WordLength < 1 || this.WordLength > MaxWordLength {
+		return fmt.Errorf("%w: word length %d is not between 1 and %d", ErrInvalidRules, this.WordLength, MaxWordLength)
+	}
+	if this.MaxNumGuesses < 1 {
+		return fmt.Errorf("%w: at least 1 guess has to be allowed, not %d", ErrInvalidRules, this.MaxNumGuesses)
+	}
+	return nil
+}
+
+// CorrectPattern returns the all green pattern for these rules
+func (this Rules) CorrectPattern() Pattern {
+	return correctPattern(this.WordLength)
+}
+
+// filterWords returns the words that have the right length for these rules
+func (this Rules) filterWords(words []string) []string {
+	var filtered []string
+	for _, word := range words {
+		if NumLetters(word) == this.WordLength {
+			filtered = append(filtered, word)
+		}
+	}
+	return filtered
+}
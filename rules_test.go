@@ -0,0 +1,42 @@
+package wordle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRulesValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+		valid bool
+	}{
+		{name: "default", rules: DefaultRules, valid: true},
+		{name: "longest words", rules: Rules{WordLength: MaxWordLength, MaxNumGuesses: 6}, valid: true},
+		{name: "one guess", rules: Rules{WordLength: 5, MaxNumGuesses: 1}, valid: true},
+		{name: "zero value", rules: Rules{}, valid: false},
+		{name: "words too long", rules: Rules{WordLength: MaxWordLength + 1, MaxNumGuesses: 6}, valid: false},
+		{name: "no guesses", rules: Rules{WordLength: 5, MaxNumGuesses: 0}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Validate returned %v for valid rules %+v", err, tt.rules)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidRules) {
+				t.Errorf("Validate returned %v when %v was expected for rules %+v", err, ErrInvalidRules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestRulesCorrectPattern(t *testing.T) {
+	if DefaultRules.CorrectPattern() != CorrectPattern {
+		t.Errorf("CorrectPattern returned %v when %v was expected", DefaultRules.CorrectPattern(), CorrectPattern)
+	}
+	if length := (Rules{WordLength: 7}).CorrectPattern().Len(); length != 7 {
+		t.Errorf("CorrectPattern returned a pattern of length %d when 7 was expected", length)
+	}
+}
@@ -0,0 +1,187 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidShare = errors.New("invalid share text")
+
+// ShareTheme is the squares a share grid is drawn with
+type ShareTheme struct {
+	Gray   string
+	Yellow string
+	Green  string
+}
+
+var (
+	DarkTheme              = ShareTheme{Gray: "⬛", Yellow: "🟨", Green: "🟩"}
+	LightTheme             = ShareTheme{Gray: "⬜", Yellow: "🟨", Green: "🟩"}
+	HighContrastTheme      = ShareTheme{Gray: "⬛", Yellow: "🟦", Green: "🟧"}
+	HighContrastLightTheme = ShareTheme{Gray: "⬜", Yellow: "🟦", Green: "🟧"}
+)
+
+// ShareThemes are the themes by the names the commands take them by
+var ShareThemes = map[string]ShareTheme{
+	"dark":                DarkTheme,
+	"light":               LightTheme,
+	"high-contrast":       HighContrastTheme,
+	"high-contrast-light": HighContrastLightTheme,
+}
+
+func (this ShareTheme) square(color LetterColor) string {
+	switch color {
+	case Green:
+		return this.Green
+	case Yellow:
+		return this.Yellow
+	default:
+		return this.Gray
+	}
+}
+
+// Share is a game the way it is shared: the patterns it got without the guesses that got them
+type Share struct {
+	PuzzleNumber  *int      // the number of the day's puzzle, nil if it isn't one
+	Patterns      []Pattern // the pattern of every guess, in order
+	MaxNumGuesses int       // the number of guesses that were allowed
+	HardMode      bool      // whether the game was played in hard mode, which is marked with an asterisk
+}
+
+// NewShare returns the share for a game with the turns played by the rules, which was the daily puzzle with the number
+// unless it is nil
+func NewShare(turns []Turn, rules Rules, puzzleNumber *int) Share {
+	share := Share{PuzzleNumber: puzzleNumber, MaxNumGuesses: rules.MaxNumGuesses, HardMode: rules.HardMode}
+	for _, turn := range turns {
+		share.Patterns = append(share.Patterns, turn.Pattern)
+	}
+	return share
+}
+
+// Share returns the share for the game, which was the daily puzzle with the number unless it is nil
+func (this *Game) Share(puzzleNumber *int) Share {
+	return NewShare(this.turns, this.rules, puzzleNumber)
+}
+
+// Won returns true if the last pattern is correct
+func (this Share) Won() bool {
+	return len(this.Patterns) > 0 && this.Patterns[len(this.Patterns)-1].IsCorrect()
+}
+
+// Score returns the number of guesses it took to win, or "X" if the game wasn't won
+func (this Share) Score() string {
+	if !this.Won() {
+		return "X"
+	}
+	return strconv.Itoa(len(this.Patterns))
+}
+
+// Format writes the share the way the original game does, with a header like "Wordle 1,234 3/6*" followed by a blank
+// line and a row of squares from the theme for every guess
+func (this Share) Format(theme ShareTheme) string {
+	var text strings.Builder
+	text.WriteString("Wordle ")
+	if this.PuzzleNumber != nil {
+		text.WriteString(formatPuzzleNumber(*this.PuzzleNumber) + " ")
+	}
+	fmt.Fprintf(&text, "%s/%d", this.Score(), this.MaxNumGuesses)
+	if this.HardMode {
+		text.WriteString("*")
+	}
+	text.WriteString("\n")
+	for _, pattern := range this.Patterns {
+		text.WriteString("\n")
+		for _, color := range pattern.Colors() {
+			text.WriteString(theme.square(color))
+		}
+	}
+	return text.String()
+}
+
+// String formats the share with the DarkTheme
+func (this Share) String() string {
+	return this.Format(DarkTheme)
+}
+
+// formatPuzzleNumber writes the number with a comma between every 3 digits
+func formatPuzzleNumber(number int) string {
+	digits := strconv.Itoa(number)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+var shareHeader = regexp.MustCompile(`^Wordle\s+(?:([\d,.\s]*\d)\s+)?([1-9]\d*|[Xx])/([1-9]\d*)(\*?)$`)
+
+// ParseShare reads a share written by Format with any theme. Lines before the header and after the grid are ignored,
+// so the text can be pasted from a message.
+func ParseShare(text string) (Share, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	header := -1
+	var match []string
+	for i, line := range lines {
+		if match = shareHeader.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			header = i
+			break
+		}
+	}
+	if header < 0 {
+		return Share{}, fmt.Errorf("%w: no \"Wordle N X/6\" header", ErrInvalidShare)
+	}
+
+	var share Share
+	if match[1] != "" {
+		number, err := strconv.Atoi(strings.Map(keepDigits, match[1]))
+		if err != nil {
+			return Share{}, fmt.Errorf("%w: puzzle number \"%s\": %w", ErrInvalidShare, match[1], err)
+		}
+		share.PuzzleNumber = &number
+	}
+	share.MaxNumGuesses, _ = strconv.Atoi(match[3])
+	share.HardMode = match[4] == "*"
+
+	grid := lines[header+1:]
+	for len(grid) > 0 && strings.TrimSpace(grid[0]) == "" {
+		grid = grid[1:]
+	}
+	for _, line := range grid {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		pattern, err := ParsePattern(line)
+		if err != nil {
+			return Share{}, fmt.Errorf("%w: %w", ErrInvalidShare, err)
+		}
+		if len(share.Patterns) > 0 && pattern.Len() != share.Patterns[0].Len() {
+			return Share{}, fmt.Errorf("%w: rows have different lengths", ErrInvalidShare)
+		}
+		share.Patterns = append(share.Patterns, pattern)
+	}
+
+	for i, pattern := range share.Patterns {
+		if pattern.IsCorrect() && i != len(share.Patterns)-1 {
+			return Share{}, fmt.Errorf("%w: row %d is correct but isn't the last", ErrInvalidShare, i+1)
+		}
+	}
+	if score := share.Score(); !strings.EqualFold(score, match[2]) {
+		return Share{}, fmt.Errorf("%w: the header says %s but the grid says %s", ErrInvalidShare, match[2], score)
+	}
+	if len(share.Patterns) > share.MaxNumGuesses {
+		return Share{}, fmt.Errorf("%w: %d rows but only %d guesses allowed", ErrInvalidShare, len(share.Patterns), share.MaxNumGuesses)
+	}
+	if !share.Won() && len(share.Patterns) != share.MaxNumGuesses {
+		return Share{}, fmt.Errorf("%w: a lost game has %d rows, not %d", ErrInvalidShare, share.MaxNumGuesses, len(share.Patterns))
+	}
+	return share, nil
+}
+
+func keepDigits(r rune) rune {
+	if r < '0' || r > '9' {
+		return -1
+	}
+	return r
+}
@@ -0,0 +1,89 @@
+package wordle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestShareFixture(t *testing.T) {
+	gunit.Run(new(ShareFixture), t)
+}
+
+type ShareFixture struct {
+	*gunit.Fixture
+}
+
+func (this *ShareFixture) wonShare() Share {
+	game, _ := NewGame("crank", nil, Rules{WordLength: 5, MaxNumGuesses: 6, HardMode: true})
+	game.Submit("salet")
+	game.Submit("angry")
+	game.Submit("crank")
+	puzzleNumber := 1234
+	return game.Share(&puzzleNumber)
+}
+
+func (this *ShareFixture) TestFormat() {
+	this.So(this.wonShare().String(), should.Equal, "Wordle 1,234 3/6*\n\n⬛🟨⬛⬛⬛\n🟨🟨⬛🟨⬛\n🟩🟩🟩🟩🟩")
+	this.So(this.wonShare().Format(HighContrastLightTheme), should.Equal, "Wordle 1,234 3/6*\n\n⬜🟦⬜⬜⬜\n🟦🟦⬜🟦⬜\n🟧🟧🟧🟧🟧")
+}
+
+func (this *ShareFixture) TestFormatLostWithoutPuzzleNumber() {
+	turns := []Turn{{"salet", CheckGuess("crank", "salet")}, {"angry", CheckGuess("crank", "angry")}}
+	share := NewShare(turns, Rules{WordLength: 5, MaxNumGuesses: 2}, nil)
+	this.So(share.Won(), should.BeFalse)
+	this.So(share.String(), should.Equal, "Wordle X/2\n\n⬛🟨⬛⬛⬛\n🟨🟨⬛🟨⬛")
+}
+
+func (this *ShareFixture) TestFormatPuzzleZero() {
+	puzzleNumber := 0
+	share := NewShare([]Turn{{"crank", CheckGuess("crank", "crank")}}, DefaultRules, &puzzleNumber)
+	this.So(share.String(), should.Equal, "Wordle 0 1/6\n\n🟩🟩🟩🟩🟩")
+	parsed, err := ParseShare(share.String())
+	this.So(err, should.BeNil)
+	this.So(parsed.PuzzleNumber, should.NotBeNil)
+	this.So(*parsed.PuzzleNumber, should.Equal, 0)
+}
+
+func (this *ShareFixture) TestFormatPuzzleNumber() {
+	this.So(formatPuzzleNumber(7), should.Equal, "7")
+	this.So(formatPuzzleNumber(999), should.Equal, "999")
+	this.So(formatPuzzleNumber(1000), should.Equal, "1,000")
+	this.So(formatPuzzleNumber(1234567), should.Equal, "1,234,567")
+}
+
+func (this *ShareFixture) TestParseRoundTrip() {
+	share := this.wonShare()
+	for _, theme := range ShareThemes {
+		parsed, err := ParseShare(share.Format(theme))
+		this.So(err, should.BeNil)
+		this.So(parsed, should.Resemble, share)
+	}
+}
+
+func (this *ShareFixture) TestParsePastedText() {
+	share, err := ParseShare("look at this\r\nWordle 1.234 X/6\r\n\r\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n🟩🟩⬛🟩🟩\n\nsee you tomorrow")
+	this.So(err, should.BeNil)
+	this.So(*share.PuzzleNumber, should.Equal, 1234)
+	this.So(share.Patterns, should.HaveLength, 6)
+	this.So(share.Won(), should.BeFalse)
+	this.So(share.HardMode, should.BeFalse)
+}
+
+func (this *ShareFixture) TestParseInvalid() {
+	for _, text := range []string{
+		"",
+		"Wordle 3/6",
+		"Wordle 12 2/6\n\n🟩🟩🟩🟩🟩\n🟩🟩🟩🟩🟩",
+		"Wordle 12 3/6\n\n⬛⬛⬛⬛⬛\n🟩🟩🟩🟩🟩",
+		"Wordle 12 X/6\n\n⬛⬛⬛⬛⬛",
+		"Wordle 12 2/6\n\n⬛⬛⬛⬛\n🟩🟩🟩🟩🟩",
+		"Wordle 12 2/1\n\n⬛⬛⬛⬛⬛\n🟩🟩🟩🟩🟩",
+		"Wordle 12 1/6\n\n🟩🟩🟩🟩🟥",
+	} {
+		_, err := ParseShare(text)
+		this.So(errors.Is(err, ErrInvalidShare), should.BeTrue)
+	}
+}
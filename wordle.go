@@ -3,10 +3,9 @@ package wordle
 import (
 	"errors"
 	"fmt"
-	"math/rand"
-
-	"github.com/tliddle1/wordle/data"
-	"github.com/tliddle1/wordle/pkg/set"
+	"io"
+	"os"
+	"time"
 )
 
 type Solver interface {
@@ -18,133 +17,123 @@ type Solver interface {
 	Reset()
 }
 
+// ErrorReporter is implemented by solvers that can fail to come up with a guess, like ones that run in another process.
+// The Evaluator checks Err after every guess and ends the game with the error if there is one.
+type ErrorReporter interface {
+	// Err returns why the last guess failed, or nil if it didn't
+	Err() error
+}
+
+// SolverFactory returns a new Solver that shares no state with any other Solver it has returned
+type SolverFactory func() Solver
+
 var (
-	ErrInvalidGuess       = errors.New("invalid guess")
-	ErrInvalidLengthGuess = errors.New("guess is not 5 letters")
-	ErrLostGame           = errors.New("a game took longer than the maximum number of guesses")
-	CorrectPattern        = Pattern{Green, Green, Green, Green, Green}
-	grayPattern           = Pattern{Gray, Gray, Gray, Gray, Gray}
+	ErrInvalidGuess        = errors.New("invalid guess")
+	ErrInvalidLengthGuess  = errors.New("guess does not have the right number of letters")
+	ErrLostGame            = errors.New("a game took longer than the maximum number of guesses")
+	ErrHardModeViolation   = errors.New("guess does not use every revealed hint")
+	ErrInvalidRules        = errors.New("invalid rules")
+	ErrGuessTimeout        = errors.New("solver took too long to guess")
+	ErrInvalidTarget       = errors.New("invalid target")
+	ErrGameOver            = errors.New("the game is over")
+	ErrInvalidGame         = errors.New("invalid saved game")
+	ErrInconsistentHistory = errors.New("no target fits the turn history")
+	ErrUnknownRenderer     = errors.New("unknown renderer")
+	CorrectPattern         = Pattern{Green, Green, Green, Green, Green}
 )
 
 const (
 	WordLength    = 5
 	MaxNumGuesses = 6
+	MaxWordLength = 10
 )
 
+// The zero LetterColor is not a color, it marks the end of a Pattern shorter than MaxWordLength
 const (
-	Gray LetterColor = iota
+	Gray LetterColor = iota + 1
 	Yellow
 	Green
 )
 
-type LetterColor int
+type LetterColor uint8
 
-// Pattern is the clue for a wordle guess
-type Pattern [5]LetterColor
+// Pattern is the clue for a wordle guess. Only the first Len colors are set, the rest are zero.
+type Pattern [MaxWordLength]LetterColor
 
-// Turn is a guess with its respective pattern
-type Turn struct {
-	Guess   string  // the word that was guessed
-	Pattern Pattern // the pattern returned by the wordle game for that guess
+// Len returns the number of letters the pattern has colors for
+func (this Pattern) Len() int {
+	for i, color := range this {
+		if color == 0 {
+			return i
+		}
+	}
+	return len(this)
 }
 
-type Evaluator struct {
-	validTargetSlice []string
-	validGuessSet    set.Set[string]
+// Colors returns the colors that are set
+func (this Pattern) Colors() []LetterColor {
+	return this[:this.Len()]
 }
 
-func NewEvaluator() *Evaluator {
-
-	evaluator := Evaluator{
-		validTargetSlice: make([]string, len(data.ValidTargets)),
-		validGuessSet:    set.Set[string]{},
-	}
-	copy(evaluator.validTargetSlice, data.ValidTargets)
-	for _, guess := range data.ValidTargets {
-		evaluator.validGuessSet.Add(guess)
-	}
-	for _, guess := range data.ValidGuesses {
-		evaluator.validGuessSet.Add(guess)
+// IsCorrect returns true if the pattern is all green, meaning the guess was the target
+func (this Pattern) IsCorrect() bool {
+	colors := this.Colors()
+	for _, color := range colors {
+		if color != Green {
+			return false
+		}
 	}
-	rand.Shuffle(len(evaluator.validTargetSlice), func(i, j int) {
-		evaluator.validTargetSlice[i], evaluator.validTargetSlice[j] = evaluator.validTargetSlice[j], evaluator.validTargetSlice[i]
-	})
-	return &evaluator
+	return len(colors) > 0
 }
 
-// EvaluateSolver will return the average number of guesses that a solver needs to solve all wordles
-func (this *Evaluator) EvaluateSolver(solver Solver) (float32, error) {
-	debug := solver.Debug()
-	var totalGuesses int
-	i := 0
-	mostGuesses := 0
-	for _, targetString := range this.validTargetSlice {
-		i++
-		if i%50 == 0 {
-			fmt.Printf("%d/%d completed\n", i, len(this.validTargetSlice))
-		}
-		solver.Reset()
-		if debug {
-			fmt.Println("target:", targetString)
-		}
-		numGuesses, err := this.PlayGame(targetString, solver)
-		if err != nil {
-			return -1, err
-		}
-		totalGuesses += numGuesses
-		mostGuesses = max(numGuesses, mostGuesses)
-		if debug {
-			break
-		}
+// correctPattern returns the all green pattern for words with wordLength letters
+func correctPattern(wordLength int) (pattern Pattern) {
+	for i := range wordLength {
+		pattern[i] = Green
 	}
-	fmt.Printf("Longest game took %d guesses.\n", mostGuesses)
-	return float32(totalGuesses) / float32(len(this.validTargetSlice)), nil
+	return pattern
+}
+
+// Turn is a guess with its respective pattern
+type Turn struct {
+	Guess   string  `json:"guess"`   // the word that was guessed
+	Pattern Pattern `json:"pattern"` // the pattern returned by the wordle game for that guess
 }
 
-// PlayGame will simulate a single game of wordle
-func (this *Evaluator) PlayGame(target string, solver Solver) (int, error) {
-	debug := solver.Debug()
-	var turnHistory []Turn
+// GuessTimeoutError is the reason a game was lost when the solver took too long to make a guess
+type GuessTimeoutError struct {
+	Target  string
+	Turn    int // the guess the solver was working on, starting at 1
+	Timeout time.Duration
+}
 
-	for i := 1; i <= MaxNumGuesses; i++ {
-		guess := solver.Guess(turnHistory)
-		if len(guess) != WordLength {
-			return -1, fmt.Errorf("%w: \"%s\"", ErrInvalidLengthGuess, guess)
-		}
-		if !this.validGuessSet.Contains(guess) {
-			return -1, fmt.Errorf("%w: \"%s\"", ErrInvalidGuess, guess)
-		}
+func (this *GuessTimeoutError) Error() string {
+	return fmt.Sprintf("%s: no guess %d for %s after %s", ErrGuessTimeout, this.Turn, this.Target, this.Timeout)
+}
 
-		pattern := CheckGuess(target, guess)
-		if pattern == CorrectPattern {
-			if debug {
-				PrintPattern(CorrectPattern, target)
-				fmt.Println(i, "guesses")
-			}
-			return i, nil
-		}
-		if debug {
-			PrintPattern(pattern, guess)
-		}
-		turnHistory = append(turnHistory, Turn{guess, pattern})
-	}
-	if debug {
-		fmt.Printf("The word was: %s\n", target)
-	}
-	return MaxNumGuesses, fmt.Errorf("%w: %s", ErrLostGame, target)
+func (this *GuessTimeoutError) Unwrap() error {
+	return ErrGuessTimeout
 }
 
-// CheckGuess will return the pattern of a guess for a particular target
+// CheckGuess will return the pattern of a guess for a particular target. The target and the guess should have the
+// same length, which can be at most MaxWordLength.
 func CheckGuess(target, guess string) Pattern {
-	return checkGuess([]byte(target), []byte(guess))
+	if isASCII(target) && isASCII(guess) {
+		return checkGuess([]byte(target), []byte(guess))
+	}
+	return checkGuess([]rune(target), []rune(guess))
 }
 
-func checkGuess(target, guess []byte) Pattern {
-	used := make([]bool, WordLength)
-	pattern := grayPattern
+// checkGuess compares the letters of the words, which are bytes if both words are ASCII and runes otherwise
+func checkGuess[Letter byte | rune](target, guess []Letter) Pattern {
+	var used [MaxWordLength]bool
+	var pattern Pattern
+	for i := range guess {
+		pattern[i] = Gray
+	}
 
 	// First pass: Check for exact matches (Green patterns)
-	for i := 0; i < WordLength; i++ {
+	for i := 0; i < len(guess) && i < len(target); i++ {
 		if target[i] == guess[i] {
 			pattern[i] = Green
 			used[i] = true // Mark this position as used
@@ -152,9 +141,9 @@ func checkGuess(target, guess []byte) Pattern {
 	}
 
 	// Second pass: Check for partial matches (Yellow patterns)
-	for i := 0; i < WordLength; i++ {
+	for i := 0; i < len(guess); i++ {
 		if pattern[i] == Gray {
-			for j := 0; j < WordLength; j++ {
+			for j := 0; j < len(target); j++ {
 				if !used[j] && target[j] == guess[i] {
 					pattern[i] = Yellow
 					used[j] = true // Mark this position as used
@@ -167,21 +156,16 @@ func checkGuess(target, guess []byte) Pattern {
 	return pattern
 }
 
-// PrintPattern will print the guess using the colors from the pattern for each letter
+// PrintPattern will print the guess using the colors from the pattern for each letter, with DefaultRenderer
+//
+// Deprecated: use a Renderer, like DefaultRenderer().RenderTurn(os.Stdout, turn)
 func PrintPattern(pattern Pattern, guess string) {
-	green := "\033[32m"
-	yellow := "\033[33m"
-	reset := "\033[0m"
-
-	colorizedPattern := ""
-	for i := range 5 {
-		if pattern[i] == Green {
-			colorizedPattern += green + string(guess[i]) + reset
-		} else if pattern[i] == Yellow {
-			colorizedPattern += yellow + string(guess[i]) + reset
-		} else {
-			colorizedPattern += string(guess[i])
-		}
-	}
-	fmt.Println(colorizedPattern)
+	FprintPattern(os.Stdout, pattern, guess)
+}
+
+// FprintPattern will write the guess to w using the colors from the pattern for each letter, with DefaultRenderer
+//
+// Deprecated: use a Renderer, which also reports write errors
+func FprintPattern(w io.Writer, pattern Pattern, guess string) {
+	DefaultRenderer().RenderTurn(w, Turn{guess, pattern})
 }
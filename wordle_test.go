@@ -1,10 +1,16 @@
 package wordle
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/smarty/assertions/should"
 	"github.com/smarty/gunit"
+	"github.com/tliddle1/wordle/data"
 )
 
 func TestWordleFixture(t *testing.T) {
@@ -17,19 +23,204 @@ type WordleFixture struct {
 }
 
 func (this *WordleFixture) Setup() {
-	this.Evaluator = NewEvaluator()
+	this.Evaluator = NewEvaluator(WithOutput(io.Discard))
 }
 
 func (this *WordleFixture) TestEvaluatorOneGuess() {
-	avgNumGuesses, err := this.Evaluator.EvaluateSolver(NewDummySolverOneGuess())
+	report, err := this.Evaluator.EvaluateSolver(NewDummySolverOneGuess())
+	this.So(err, should.BeNil)
+	this.So(report.Games, should.HaveLength, len(this.Evaluator.validTargetSlice))
+	this.So(report.Failures, should.HaveLength, len(report.Games))
+	this.So(report.Failures[0].Err, should.Wrap, ErrLostGame)
+	this.So(report.Failures[0].Turns, should.HaveLength, MaxNumGuesses)
+	this.So(report.WinRate, should.Equal, 0)
+}
+
+func (this *WordleFixture) TestEvaluatorOneGuessFailFast() {
+	this.Evaluator = NewEvaluator(WithOutput(io.Discard), WithFailFast())
+	report, err := this.Evaluator.EvaluateSolver(NewDummySolverOneGuess())
+	this.So(err, should.Wrap, ErrLostGame)
+	this.So(report.Games, should.HaveLength, 1)
+	this.So(report.Failures, should.HaveLength, 1)
+}
+
+func (this *WordleFixture) TestEvaluatorParallelOneGuess() {
+	this.Evaluator = NewEvaluator(WithOutput(io.Discard), WithFailFast())
+	sequentialReport, sequentialErr := this.Evaluator.EvaluateSolver(NewDummySolverOneGuess())
+	report, err := this.Evaluator.EvaluateSolverParallel(NewDummySolverOneGuess, 8)
 	this.So(err, should.Wrap, ErrLostGame)
-	this.So(avgNumGuesses, should.Equal, -1)
+	this.So(err.Error(), should.Equal, sequentialErr.Error())
+	this.So(report, should.Resemble, sequentialReport)
+}
+
+func (this *WordleFixture) TestEvaluatorParallelSolverPerWorker() {
+	var numSolvers int
+	newSolver := func() Solver {
+		numSolvers++
+		return NewDummySolverOneGuess()
+	}
+	_, _ = this.Evaluator.EvaluateSolverParallel(newSolver, 4)
+	this.So(numSolvers, should.Equal, 4)
+}
+
+func (this *WordleFixture) TestEvaluatorDebugWithoutTargets() {
+	evaluator := NewEvaluator(WithTargets([]string{}), WithOutput(io.Discard))
+	report, err := evaluator.EvaluateSolver(debugSolver{NewDummySolverOneGuess()})
+	this.So(err, should.BeNil)
+	this.So(report.Games, should.BeEmpty)
+}
+
+func (this *WordleFixture) TestEvaluatorSeed() {
+	first := NewEvaluator(WithSeed(42)).Targets()
+	second := NewEvaluator(WithSeed(42)).Targets()
+	this.So(first, should.Resemble, second)
+	this.So(first, should.NotResemble, data.ValidTargets)
+}
+
+func (this *WordleFixture) TestEvaluatorWithoutShuffle() {
+	this.So(NewEvaluator(WithoutShuffle()).Targets(), should.Resemble, data.ValidTargets)
+}
+
+func (this *WordleFixture) TestEvaluatorCustomWords() {
+	evaluator := NewEvaluator(WithTargets([]string{"angry", "crane"}), WithGuesses([]string{"salet"}), WithoutShuffle())
+	this.So(evaluator.Targets(), should.Resemble, []string{"angry", "crane"})
+	this.So(evaluator.validGuessSet, should.HaveLength, 3)
+}
+
+func (this *WordleFixture) TestEvaluatorSampleSize() {
+	evaluator := NewEvaluator(WithSampleSize(3), WithoutShuffle())
+	this.So(evaluator.Targets(), should.Resemble, data.ValidTargets[:3])
+}
+
+func (this *WordleFixture) TestEvaluatorOutput() {
+	var output bytes.Buffer
+	evaluator := NewEvaluator(WithSampleSize(100), WithOutput(&output))
+	_, _ = evaluator.EvaluateSolver(NewDummySolverOneGuess())
+	this.So(output.String(), should.Equal, "50/100 completed\n100/100 completed\n")
+}
+
+func (this *WordleFixture) TestEvaluatorProgress() {
+	var calls []int
+	var output bytes.Buffer
+	progress := func(completed, total int) {
+		calls = append(calls, completed)
+		this.So(total, should.Equal, 3)
+	}
+	evaluator := NewEvaluator(WithSampleSize(3), WithOutput(&output), WithProgress(progress))
+	_, _ = evaluator.EvaluateSolverParallel(NewDummySolverOneGuess, 3)
+	this.So(calls, should.Resemble, []int{1, 2, 3})
+	this.So(output.String(), should.BeEmpty)
+}
+
+func (this *WordleFixture) TestPlayGameHardMode() {
+	evaluator := NewEvaluator(WithHardMode())
+	numGuesses, err := evaluator.PlayGame("sheen", NewDummySolverFixedGuesses("siren", "seven", "sheen"))
+	this.So(err, should.BeNil)
+	this.So(numGuesses, should.Equal, 3)
+
+	numGuesses, err = evaluator.PlayGame("sheen", NewDummySolverFixedGuesses("siren", "salet", "sheen"))
+	this.So(err, should.Wrap, ErrHardModeViolation)
+	this.So(numGuesses, should.Equal, -1)
+}
+
+func (this *WordleFixture) TestEvaluatorRules() {
+	rules := Rules{WordLength: 4, MaxNumGuesses: 7}
+	evaluator := NewEvaluator(WithRules(rules), WithTargets([]string{"tree", "trees"}), WithGuesses([]string{"even"}))
+	this.So(evaluator.Targets(), should.Resemble, []string{"tree"})
+	numGuesses, err := evaluator.PlayGame("tree", NewDummySolverFixedGuesses("even", "even", "even", "even", "even", "even", "tree"))
+	this.So(err, should.BeNil)
+	this.So(numGuesses, should.Equal, 7)
+}
+
+func (this *WordleFixture) TestEvaluatorInvalidRules() {
+	evaluator := NewEvaluator(WithRules(Rules{WordLength: MaxWordLength + 1, MaxNumGuesses: 6}))
+	_, err := evaluator.EvaluateSolver(NewDummySolverOneGuess())
+	this.So(err, should.Wrap, ErrInvalidRules)
+}
+
+func (this *WordleFixture) TestPatternLen() {
+	this.So(Pattern{}.Len(), should.Equal, 0)
+	this.So(Pattern{Gray, Yellow, Green}.Len(), should.Equal, 3)
+	this.So(CorrectPattern.Len(), should.Equal, WordLength)
+}
+
+func (this *WordleFixture) TestPatternIsCorrect() {
+	this.So(CorrectPattern.IsCorrect(), should.BeTrue)
+	this.So(Pattern{Green, Green, Green}.IsCorrect(), should.BeTrue)
+	this.So(Pattern{Green, Green, Yellow}.IsCorrect(), should.BeFalse)
+	this.So(Pattern{}.IsCorrect(), should.BeFalse)
+}
+
+func (this *WordleFixture) TestPlayGameGuessTimeout() {
+	release := make(chan struct{})
+	defer close(release)
+	evaluator := NewEvaluator(WithGuessTimeout(10 * time.Millisecond))
+	numGuesses, err := evaluator.PlayGame("angry", NewDummySolverHanging(release, 2))
+	this.So(err, should.Wrap, ErrGuessTimeout)
+	this.So(err, should.Resemble, &GuessTimeoutError{Target: "angry", Turn: 3, Timeout: 10 * time.Millisecond})
+	this.So(numGuesses, should.Equal, -1)
+}
+
+func (this *WordleFixture) TestEvaluatorGuessTimeoutStopsEvaluation() {
+	release := make(chan struct{})
+	defer close(release)
+	evaluator := NewEvaluator(WithOutput(io.Discard), WithGuessTimeout(10*time.Millisecond))
+	report, err := evaluator.EvaluateSolverParallel(func() Solver { return NewDummySolverHanging(release, 0) }, 4)
+	this.So(err, should.Wrap, ErrGuessTimeout)
+	this.So(err.(*GuessTimeoutError).Target, should.Equal, evaluator.Targets()[0])
+	this.So(report.Games, should.HaveLength, 1)
+}
+
+func (this *WordleFixture) TestPlayGameContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	numGuesses, err := this.Evaluator.PlayGameContext(ctx, "angry", NewDummySolverOneGuess())
+	this.So(err, should.Equal, context.Canceled)
+	this.So(numGuesses, should.Equal, -1)
+}
+
+func (this *WordleFixture) TestEvaluatorContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	progress := func(completed, total int) {
+		if completed == 3 {
+			cancel()
+		}
+	}
+	evaluator := NewEvaluator(WithProgress(progress))
+	report, err := evaluator.EvaluateSolverContext(ctx, NewDummySolverOneGuess())
+	this.So(err, should.Equal, context.Canceled)
+	this.So(report.Games, should.HaveLength, 3)
+	this.So(report.Failures, should.HaveLength, 3)
+}
+
+func (this *WordleFixture) TestPlayAdversarialGame() {
+	evaluator := NewEvaluator(WithTargets([]string{"angry", "crane", "crank", "drank"}))
+	game := evaluator.PlayAdversarialGame(NewDummySolverFixedGuesses("crank", "angry"))
+	this.So(game.Err, should.BeNil)
+	this.So(game.Target, should.Equal, "angry")
+	this.So(game.NumGuesses, should.Equal, 2)
+	this.So(game.Turns[0].Pattern, should.Equal, Pattern{Gray, Yellow, Yellow, Yellow, Gray})
+	this.So(game.Turns[1].Pattern.IsCorrect(), should.BeTrue)
+}
+
+func (this *WordleFixture) TestPlayAdversarialGameLost() {
+	evaluator := NewEvaluator(WithTargets([]string{"angry", "crane"}))
+	game := evaluator.PlayAdversarialGame(NewDummySolverOneGuess())
+	this.So(game.Err, should.Wrap, ErrLostGame)
+	this.So(game.NumGuesses, should.Equal, MaxNumGuesses)
+}
+
+func (this *WordleFixture) TestSolverReportsError() {
+	errOutOfIdeas := errors.New("out of ideas")
+	_, err := this.Evaluator.PlayGame("salet", NewDummySolverFailing(errOutOfIdeas))
+	this.So(err, should.Equal, errOutOfIdeas)
 }
 
 func (this *WordleFixture) NewDummySolverInvalidGuess() {
-	avgNumGuesses, err := this.Evaluator.EvaluateSolver(NewDummySolverInvalidGuess())
-	this.So(err, should.Equal, ErrInvalidGuess)
-	this.So(avgNumGuesses, should.Equal, -1)
+	report, err := this.Evaluator.EvaluateSolver(NewDummySolverInvalidGuess())
+	this.So(err, should.BeNil)
+	this.So(report.Failures[0].Err, should.Equal, ErrInvalidGuess)
 }
 
 func TestIsValidTarget(t *testing.T) {
@@ -44,6 +235,8 @@ func TestIsValidTarget(t *testing.T) {
 		{name: "two yellows same letter", target: "sheen", guess: "elate", expected: Pattern{Yellow, Gray, Gray, Gray, Yellow}},
 		{name: "two letters one yellow", target: "messy", guess: "sheen", expected: Pattern{Yellow, Gray, Yellow, Gray, Gray}},
 		{name: "lots of repeated letters", target: "freer", guess: "error", expected: Pattern{Yellow, Green, Gray, Gray, Green}},
+		{name: "four letters", target: "tree", guess: "even", expected: Pattern{Yellow, Gray, Green, Gray}},
+		{name: "six letters", target: "banana", guess: "cabana", expected: Pattern{Gray, Green, Yellow, Green, Green, Green}},
 	}
 
 	for _, tt := range tests {
@@ -91,3 +284,72 @@ func (this DummySolverInvalidGuess) Guess(turnHistory []Turn) string {
 }
 
 func (this DummySolverInvalidGuess) Reset() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type DummySolverFixedGuesses struct {
+	guesses []string
+}
+
+func NewDummySolverFixedGuesses(guesses ...string) Solver {
+	return &DummySolverFixedGuesses{guesses: guesses}
+}
+
+func (this DummySolverFixedGuesses) Debug() bool {
+	return false
+}
+
+func (this DummySolverFixedGuesses) Guess(turnHistory []Turn) string {
+	return this.guesses[len(turnHistory)]
+}
+
+func (this DummySolverFixedGuesses) Reset() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// DummySolverHanging makes a few guesses and then doesn't return from Guess until it is released
+type DummySolverHanging struct {
+	release    chan struct{}
+	numGuesses int
+}
+
+func NewDummySolverHanging(release chan struct{}, numGuesses int) Solver {
+	return &DummySolverHanging{release: release, numGuesses: numGuesses}
+}
+
+func (this DummySolverHanging) Debug() bool {
+	return false
+}
+
+func (this DummySolverHanging) Guess(turnHistory []Turn) string {
+	if len(turnHistory) >= this.numGuesses {
+		<-this.release
+	}
+	return "salet"
+}
+
+func (this DummySolverHanging) Reset() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type DummySolverFailing struct {
+	err error
+}
+
+func NewDummySolverFailing(err error) Solver {
+	return &DummySolverFailing{err: err}
+}
+
+func (this DummySolverFailing) Debug() bool {
+	return false
+}
+
+func (this DummySolverFailing) Guess(turnHistory []Turn) string {
+	return ""
+}
+
+func (this DummySolverFailing) Reset() {}
+
+func (this DummySolverFailing) Err() error {
+	return this.err
+}
@@ -0,0 +1,71 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+var ErrUnknownLanguage = errors.New("no word pack for the language")
+
+// DefaultLanguage is the language of the word lists in the data package, which are bundled as its word pack
+const DefaultLanguage = "en"
+
+var languageCode = regexp.MustCompile(`^[a-z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// DefaultWordPackDir returns where word packs are looked for unless another directory is given, which is wordle/packs
+// in the user's config directory
+func DefaultWordPackDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wordle", "packs")
+}
+
+// LoadLanguage returns the dictionary of the word pack for the language code, like "es" or "pt-BR". A word pack is a
+// directory named after the code in dir with a targets file and optionally a guesses file, named targets.txt or
+// targets.json and guesses.txt or guesses.json, in the formats LoadDictionary takes. DefaultLanguage is bundled, so it
+// only needs a word pack to replace the bundled lists.
+func LoadLanguage(code, dir string) (*Dictionary, error) {
+	if !languageCode.MatchString(code) {
+		return nil, fmt.Errorf("%w: \"%s\" is not a language code", ErrUnknownLanguage, code)
+	}
+	packDir := filepath.Join(dir, code)
+	targetsPath := findWordList(packDir, "targets")
+	if dir == "" || targetsPath == "" {
+		if code == DefaultLanguage {
+			return DefaultDictionary(), nil
+		}
+		return nil, fmt.Errorf("%w: \"%s\" (no targets in %s)", ErrUnknownLanguage, code, packDir)
+	}
+	return LoadDictionary(targetsPath, findWordList(packDir, "guesses"))
+}
+
+// Languages returns the codes of the bundled word pack and of the word packs in dir
+func Languages(dir string) []string {
+	languages := []string{DefaultLanguage}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		code := entry.Name()
+		if entry.IsDir() && languageCode.MatchString(code) && findWordList(filepath.Join(dir, code), "targets") != "" {
+			languages = append(languages, code)
+		}
+	}
+	slices.Sort(languages)
+	return slices.Compact(languages)
+}
+
+// findWordList returns the path of the word list with the name in dir, or "" if there isn't one
+func findWordList(dir, name string) string {
+	for _, extension := range []string{".txt", ".json"} {
+		path := filepath.Join(dir, name+extension)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
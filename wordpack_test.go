@@ -0,0 +1,77 @@
+package wordle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestWordPackFixture(t *testing.T) {
+	gunit.Run(new(WordPackFixture), t)
+}
+
+type WordPackFixture struct {
+	*gunit.Fixture
+	dir string
+}
+
+func (this *WordPackFixture) Setup() {
+	var err error
+	this.dir, err = os.MkdirTemp("", "packs")
+	this.So(err, should.BeNil)
+	this.writePack("es", "targets.txt", "ÑANDÚ\nárbol\n")
+	this.writePack("es", "guesses.txt", "ñandu\nñandú\nárbol\nabrir\n")
+	this.writePack("el", "targets.json", `["λόγος", "ΜΎΘΟΣ"]`)
+	this.writePack("de", "readme.md", "no targets yet")
+}
+
+func (this *WordPackFixture) Teardown() {
+	os.RemoveAll(this.dir)
+}
+
+func (this *WordPackFixture) writePack(code, name, contents string) {
+	this.So(os.MkdirAll(filepath.Join(this.dir, code), 0o755), should.BeNil)
+	this.So(os.WriteFile(filepath.Join(this.dir, code, name), []byte(contents), 0o600), should.BeNil)
+}
+
+func (this *WordPackFixture) TestLoad() {
+	dictionary, err := LoadLanguage("es", this.dir)
+	this.So(err, should.BeNil)
+	this.So(dictionary.Targets(), should.Resemble, []string{"ñandú", "árbol"})
+	this.So(dictionary.WordLength(), should.Equal, 5)
+	this.So(dictionary.Contains("abrir"), should.BeTrue)
+}
+
+func (this *WordPackFixture) TestLoadReportsBadWords() {
+	this.writePack("es", "targets.txt", "ñandú\nnación\n")
+	_, err := LoadLanguage("es", this.dir)
+	this.So(err.Error(), should.ContainSubstring, "targets.txt:2: \"nación\" does not have 5 letters")
+}
+
+func (this *WordPackFixture) TestJSONPack() {
+	dictionary, err := LoadLanguage("el", this.dir)
+	this.So(err, should.BeNil)
+	this.So(dictionary.Guesses(), should.Resemble, []string{"λόγος", "μύθοσ"})
+}
+
+func (this *WordPackFixture) TestBundled() {
+	dictionary, err := LoadLanguage(DefaultLanguage, this.dir)
+	this.So(err, should.BeNil)
+	this.So(dictionary.Targets(), should.Resemble, DefaultDictionary().Targets())
+}
+
+func (this *WordPackFixture) TestUnknown() {
+	for _, code := range []string{"de", "fr", "../es", ""} {
+		_, err := LoadLanguage(code, this.dir)
+		this.So(errors.Is(err, ErrUnknownLanguage), should.BeTrue)
+	}
+}
+
+func (this *WordPackFixture) TestLanguages() {
+	this.So(Languages(this.dir), should.Resemble, []string{"el", "en", "es"})
+	this.So(Languages(filepath.Join(this.dir, "missing")), should.Resemble, []string{"en"})
+}